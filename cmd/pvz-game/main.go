@@ -1,14 +1,80 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"log"
+	"os"
 	"pvz/internal/core"
+	"pvz/internal/sim"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
 func main() {
+	seed := flag.Uint64("seed", 0, "Simulation RNG seed (picked from the clock if not set)")
+	record := flag.String("record", "", "Write a replay of this session to the given file on exit")
+	replay := flag.String("replay", "", "Play back a replay file (overrides -seed)")
+	plants := flag.String("plants", "public/data/plants.json", "Plant stats file")
+	zombies := flag.String("zombies", "public/data/zombies.json", "Zombie stats file")
+	level := flag.Int("level", 1, "Level to play")
+	endless := flag.String("endless", "", "Play endless mode with the given settings file (e.g. public/data/endless.json)")
+	levels := flag.String("levels", "public/data/levels.json", "Level file (procedural waves if missing or the level isn't in it)")
+	flag.Parse()
+
+	// Any seed is valid, 0 included, so only an absent flag picks one
+	seedSet := false
+	flag.Visit(func(f *flag.Flag) { seedSet = seedSet || f.Name == "seed" })
+	if !seedSet {
+		*seed = uint64(time.Now().UnixNano())
+	}
+
 	game := core.NewGame()
+	game.World.SetSeed(*seed)
+
+	plantData, perr := os.ReadFile(*plants)
+	zombieData, zerr := os.ReadFile(*zombies)
+	if err := errors.Join(perr, zerr); err != nil {
+		log.Printf("game data not loaded, using defaults: %v", err)
+	} else if err := game.World.LoadRegistry(plantData, zombieData); err != nil {
+		log.Fatal(err)
+	}
+
+	if *endless != "" {
+		data, err := os.ReadFile(*endless)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg, errs := sim.ParseEndless(data, game.World.Registry.Zombies)
+		if errs != nil {
+			log.Fatal(errs.In(*endless))
+		}
+		if err := game.World.StartEndless(cfg); err != nil {
+			log.Fatal(err)
+		}
+	} else if err := loadLevel(game.World, *levels, *level); err != nil {
+		log.Fatal(err)
+	}
+
+	if *replay != "" {
+		data, err := os.ReadFile(*replay)
+		if err != nil {
+			log.Fatalf("failed to read replay: %v", err)
+		}
+		rep, err := sim.ParseReplay(data)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := game.World.Play(rep); err != nil {
+			log.Fatal(err)
+		}
+	} else if *record != "" {
+		if err := game.World.StartRecording(); err != nil {
+			log.Fatal(err)
+		}
+	}
+	log.Printf("Level seed: %d", game.World.Seed)
 
 	ebiten.SetWindowSize(800, 600)
 	ebiten.SetWindowTitle("Plants vs. Zombies - Go Port")
@@ -16,4 +82,29 @@ func main() {
 	if err := ebiten.RunGame(game); err != nil {
 		log.Fatal(err)
 	}
+
+	if rep := game.World.StopRecording(); rep != nil {
+		data, err := rep.Marshal()
+		if err != nil {
+			log.Fatalf("failed to encode replay: %v", err)
+		}
+		if err := os.WriteFile(*record, data, 0644); err != nil {
+			log.Fatalf("failed to write replay: %v", err)
+		}
+		log.Printf("Replay written to %s", *record)
+	}
+}
+
+// loadLevel starts level id from the level file, or procedural waves when
+// the file is missing or doesn't have it.
+func loadLevel(w *sim.World, path string, id int) error {
+	lvl := &sim.Level{ID: id, Waves: sim.ProceduralWaves(id)}
+	if data, err := os.ReadFile(path); err != nil {
+		log.Printf("level file not loaded, using procedural waves: %v", err)
+	} else if file, err := sim.ParseLevels(data, w.Registry.Zombies); err != nil {
+		return err
+	} else if l := file.Level(id); l != nil {
+		lvl = l
+	}
+	return w.LoadLevel(lvl)
 }
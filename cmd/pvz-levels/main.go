@@ -0,0 +1,64 @@
+// Command pvz-levels rewrites level files into the current schema.
+//
+//	pvz-levels [-o out.json] [-zombies zombies.json] levels.json
+//
+// Without -o the input file is rewritten in place. Files already in the
+// current schema are validated and reformatted. Spawns must name a zombie
+// type from -zombies, or from the stock zombies.json without it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"pvz/internal/sim"
+)
+
+func main() {
+	out := flag.String("o", "", "Output file (defaults to rewriting the input)")
+	zombiesPath := flag.String("zombies", "", "Zombie stats file spawn types are checked against (defaults to the stock one)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: pvz-levels [-o out.json] [-zombies zombies.json] levels.json\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	in := flag.Arg(0)
+	if *out == "" {
+		*out = in
+	}
+
+	data, err := os.ReadFile(in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	zombies := sim.DefaultRegistry().Zombies
+	if *zombiesPath != "" {
+		zdata, err := os.ReadFile(*zombiesPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var errs sim.FieldErrors
+		if zombies, errs = sim.ValidateZombies(zdata); errs != nil {
+			log.Fatal(errs.In(*zombiesPath))
+		}
+	}
+
+	file, err := sim.ParseLevels(data, zombies)
+	if err != nil {
+		log.Fatalf("%s: %v", in, err)
+	}
+	migrated, err := file.Marshal()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, append(migrated, '\n'), 0644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("wrote %d levels to %s (version %d)", len(file.Levels), *out, file.Version)
+}
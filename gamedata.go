@@ -0,0 +1,13 @@
+// Package pvz embeds the stock plant and zombie stats so the simulation
+// starts from exactly what the browser build loads, see sim.DefaultRegistry.
+// The JSON stays in public/data, where Vite serves it to the browser; only
+// the Go side lives here so it is not deployed with the static assets.
+package pvz
+
+import _ "embed"
+
+//go:embed public/data/plants.json
+var PlantsJSON []byte
+
+//go:embed public/data/zombies.json
+var ZombiesJSON []byte
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"image/color"
+	"maps"
+	"slices"
+
+	"pvz/internal/sim"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Plant colours and size match the stand-in shapes in Plant.js
+const plantSize = 80
+
+var plantColors = map[string]color.RGBA{
+	"peashooter":  {0x4a, 0xde, 0x80, 0xff},
+	"sunflower":   {0xfa, 0xcc, 0x15, 0xff},
+	"wallnut":     {0xa1, 0x62, 0x07, 0xff},
+	"tallnut":     {0xa1, 0x62, 0x07, 0xff},
+	"pumpkin":     {0xf9, 0x73, 0x16, 0xff},
+	"cherrybomb":  {0xdc, 0x26, 0x26, 0xff},
+	"snowpea":     {0x60, 0xa5, 0xfa, 0xff},
+	"repeater":    {0x22, 0xc5, 0x5e, 0xff},
+	"potatomine":  {0xb4, 0x53, 0x09, 0xff},
+	"threepeater": {0x10, 0xb9, 0x81, 0xff},
+	"plantern":    {0xfb, 0xbf, 0x24, 0xff},
+	"blover":      {0x16, 0xa3, 0x4a, 0xff},
+	"squash":      {0xf9, 0x73, 0x16, 0xff},
+}
+
+var crackColor = color.RGBA{0x28, 0x14, 0x00, 0xcc}
+
+func (g *Game) drawPlants(screen *ebiten.Image) {
+	w := g.World
+	for _, id := range slices.Sorted(maps.Keys(w.Plants)) {
+		p := w.Plants[id]
+		clr, ok := plantColors[p.Type]
+		if !ok {
+			clr = color.RGBA{0xff, 0xff, 0xff, 0xff}
+		}
+		cx, cy := p.X+plantSize/2, p.Y+plantSize/2
+		vector.FillCircle(screen, cx, cy, 30, clr, true)
+		drawCracks(screen, p, cx, cy)
+	}
+}
+
+// drawCracks shows a plant's wear as one crack per damage tier, the same
+// stand-in Plant.js draws for skins without their own art.
+func drawCracks(screen *ebiten.Image, p *sim.Plant, cx, cy float32) {
+	for i := range p.DamageTier {
+		dir := float32(1)
+		if i%2 == 1 {
+			dir = -1
+		}
+		dy := float32(i * 10)
+		points := [][2]float32{
+			{cx + dir*5, cy - 25 + dy},
+			{cx - dir*8, cy - 10 + dy},
+			{cx + dir*6, cy + 5 + dy},
+			{cx - dir*4, cy + 20 + dy},
+		}
+		for j := 1; j < len(points); j++ {
+			a, b := points[j-1], points[j]
+			vector.StrokeLine(screen, a[0], a[1], b[0], b[1], 2, crackColor, true)
+		}
+	}
+}
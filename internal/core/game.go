@@ -1,23 +1,95 @@
 package core
 
 import (
+	"log"
 	"pvz/internal/assets"
+	"pvz/internal/sim"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 type Game struct {
-	// Game state will go here
+	// Simulation shared with the wasm build
+	World *sim.World
+
+	// Quick checkpoint: F5 saves, F9 restores
+	checkpoint []byte
 }
 
 func NewGame() *Game {
 	assets.LoadAssets()
-	return &Game{}
+	return &Game{
+		World: sim.NewWorld(),
+	}
 }
 
+// Seeds selectable with the number keys 1-9
+var seedKeys = []string{"peashooter", "sunflower", "cherrybomb", "wallnut", "potatomine", "snowpea", "repeater", "threepeater", "squash"}
+
 func (g *Game) Update() error {
-	// Update logic goes here
+	if err := g.handleCheckpointKeys(); err != nil {
+		return err
+	}
+	g.handleInput()
+
+	// Simulation runs in milliseconds like the browser build
+	dt := float32(1000) / float32(ebiten.TPS())
+
+	// Nothing renders events natively yet, only level flow is logged
+	res := g.World.Advance(dt)
+	for _, evt := range res.Events {
+		switch evt.Type {
+		case sim.EventWaveStarted:
+			log.Printf("Wave %d", int(evt.Amount))
+		case sim.EventFlagWave:
+			log.Printf("A huge wave of zombies is approaching!")
+		case sim.EventLevelComplete:
+			log.Printf("Level complete")
+		}
+	}
+	return nil
+}
+
+// handleInput turns mouse/keyboard input into simulation commands. Submit
+// refuses input while a replay is playing, which is what we want here.
+func (g *Game) handleInput() {
+	for i, seed := range seedKeys {
+		if inpututil.IsKeyJustPressed(ebiten.Key1 + ebiten.Key(i)) {
+			g.World.Submit(sim.Command{Type: sim.CmdSelectSeed, Kind: seed})
+		}
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := ebiten.CursorPosition()
+		row, col := g.World.Grid.CellAt(float64(x), float64(y))
+		if row < 0 {
+			return
+		}
+		seed := g.World.SelectedSeed
+		if seed == "" {
+			seed = seedKeys[0]
+		}
+		g.World.Submit(sim.Command{Type: sim.CmdPlacePlant, Row: row, Col: col, Kind: seed})
+	}
+}
+
+func (g *Game) handleCheckpointKeys() error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyF5) {
+		data, err := g.World.Snapshot()
+		if err != nil {
+			return err
+		}
+		g.checkpoint = data
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF9) && g.checkpoint != nil {
+		// Refused while a replay is recording or playing; keep going rather
+		// than losing the session over a checkpoint key
+		if err := g.World.Restore(g.checkpoint); err != nil {
+			log.Printf("Checkpoint not restored: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -25,6 +97,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	if assets.BackgroundImage != nil {
 		screen.DrawImage(assets.BackgroundImage, nil)
 	}
+	g.drawPlants(screen)
 	ebitenutil.DebugPrint(screen, "PvZ Go - Early Alpha")
 }
 
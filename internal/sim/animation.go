@@ -1,4 +1,4 @@
-package main
+package sim
 
 import (
 	"math"
@@ -23,6 +23,11 @@ type Bone struct {
 	WorldScaleX float32 `json:"-"`
 	WorldScaleY float32 `json:"-"`
 
+	// World transform before the latest tick, for AppendRenderBuffer to
+	// interpolate from; hasPrev is false for bones added since then
+	prevX, prevY, prevRot, prevScaleX, prevScaleY float32
+	hasPrev                                       bool
+
 	Children []*Bone `json:"children"`
 	Parent   *Bone   `json:"-"` // prevent cycle in JSON
 }
@@ -31,6 +36,8 @@ type Skeleton struct {
 	Root  *Bone            `json:"root"`
 	X, Y  float32          `json:"-"`
 	Bones map[string]*Bone `json:"-"`
+
+	ownerID int // entity driving this skeleton; 0 for free-standing ones
 }
 
 func NewSkeleton(x, y float32) *Skeleton {
@@ -41,6 +48,71 @@ func NewSkeleton(x, y float32) *Skeleton {
 	}
 }
 
+// AddBone attaches b under the bone named parentName, or makes it the root
+// when parentName is empty. Returns false if the parent does not exist.
+func (s *Skeleton) AddBone(parentName string, b *Bone) bool {
+	if parentName == "" {
+		s.Root = b
+	} else {
+		parent, ok := s.Bones[parentName]
+		if !ok {
+			// Parent not found, strict fail rather than guessing a place for it
+			return false
+		}
+		b.Parent = parent
+		parent.Children = append(parent.Children, b)
+	}
+	s.Bones[b.Name] = b
+	return true
+}
+
+// DetachBone removes the named bone (and everything under it) from the
+// skeleton and returns it, or nil if there is no such bone. World
+// transforms on the returned bone are left as of the last Update.
+func (s *Skeleton) DetachBone(name string) *Bone {
+	b, ok := s.Bones[name]
+	if !ok {
+		return nil
+	}
+	if b.Parent != nil {
+		siblings := b.Parent.Children
+		for i, c := range siblings {
+			if c == b {
+				b.Parent.Children = append(siblings[:i:i], siblings[i+1:]...)
+				break
+			}
+		}
+		b.Parent = nil
+	} else if s.Root == b {
+		s.Root = nil
+	}
+	s.forgetBones(b)
+	return b
+}
+
+func (s *Skeleton) forgetBones(b *Bone) {
+	delete(s.Bones, b.Name)
+	for _, c := range b.Children {
+		s.forgetBones(c)
+	}
+}
+
+// SetBoneTransform overwrites a bone's local transform and recomputes world
+// transforms straight away so the editor sees the result immediately.
+func (s *Skeleton) SetBoneTransform(name string, x, y, rot, sx, sy float32) bool {
+	b, ok := s.Bones[name]
+	if !ok {
+		return false
+	}
+	b.LocalX = x
+	b.LocalY = y
+	b.Rotation = rot
+	b.ScaleX = sx
+	b.ScaleY = sy
+	s.Update(0)
+	return true
+}
+
 // Update calculates world transforms
 func (s *Skeleton) Update(dt float32) {
 	// 1. Reset Root's world state to Skeleton's world state
@@ -101,6 +173,51 @@ func (s *Skeleton) appendBoneData(b *Bone, data []float32) []float32 {
 	return data
 }
 
+// rememberTransforms records every bone's current world transform as the
+// one the next tick is interpolated from.
+func (s *Skeleton) rememberTransforms() {
+	if s.Root != nil {
+		rememberTransforms(s.Root)
+	}
+}
+
+func rememberTransforms(b *Bone) {
+	b.prevX, b.prevY, b.prevRot = b.WorldX, b.WorldY, b.WorldRot
+	b.prevScaleX, b.prevScaleY = b.WorldScaleX, b.WorldScaleY
+	b.hasPrev = true
+	for _, child := range b.Children {
+		rememberTransforms(child)
+	}
+}
+
+// appendBlendedBones encodes b and its children onto buf as render buffer
+// bone records (see render.go), every world transform blended alpha of the
+// way from its remembered value to the current one.
+func (s *Skeleton) appendBlendedBones(b *Bone, alpha float32, buf []byte) []byte {
+	x, y, rot, sx, sy := b.WorldX, b.WorldY, b.WorldRot, b.WorldScaleX, b.WorldScaleY
+	if b.hasPrev {
+		x = lerp(b.prevX, x, alpha)
+		y = lerp(b.prevY, y, alpha)
+		// Turn the short way round so a bone crossing ±π doesn't spin
+		turn := float32(math.Remainder(float64(rot-b.prevRot), 2*math.Pi))
+		rot = b.prevRot + turn*alpha
+		sx = lerp(b.prevScaleX, sx, alpha)
+		sy = lerp(b.prevScaleY, sy, alpha)
+	}
+	for _, f := range [RenderFloatsPerBone]float32{x, y, rot, sx, sy, float32(b.ImageID), b.PivotX, b.PivotY} {
+		buf = appendWord(buf, math.Float32bits(f))
+	}
+
+	for _, child := range b.Children {
+		buf = s.appendBlendedBones(child, alpha, buf)
+	}
+	return buf
+}
+
+func lerp(a, b, t float32) float32 {
+	return a + (b-a)*t
+}
+
 // --- Animation System ---
 
 type Keyframe struct {
@@ -124,6 +241,12 @@ type Animation struct {
 	Keyframes []Keyframe `json:"keyframes"`
 }
 
+// AddKeyframe appends kf. Keyframes are not kept sorted; ApplyAt scans for
+// the surrounding pair so insertion order does not matter.
+func (a *Animation) AddKeyframe(kf Keyframe) {
+	a.Keyframes = append(a.Keyframes, kf)
+}
+
 // ApplyAt applies the animation to the skeleton at a specific time
 func (a *Animation) ApplyAt(s *Skeleton, time float32, loop bool) {
 	if loop && a.Duration > 0 {
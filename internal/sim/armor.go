@@ -0,0 +1,104 @@
+package sim
+
+import "slices"
+
+// DamageKind says where damage came from. Armor only soaks the kinds it is
+// listed for; everything else goes straight to the zombie's own Health.
+type DamageKind string
+
+const (
+	DamageProjectile DamageKind = "projectile"
+	DamageExplosion  DamageKind = "explosion" // cherry bomb, potato mine
+	DamageCrush      DamageKind = "crush"     // squash
+)
+
+// Armor is an accessory with its own HP pool worn on one of the zombie's
+// bones. When it breaks the bone falls off as debris and the zombie carries
+// on with its body health, like a basic zombie.
+type Armor struct {
+	Kind      string       `json:"kind"`
+	Health    float32      `json:"health"`
+	MaxHealth float32      `json:"maxHealth"`
+	Bone      string       `json:"bone"`
+	Absorbs   []DamageKind `json:"absorbs"`
+}
+
+type armorStats struct {
+	imageID int // see ImageIDMap in Skeleton.js
+	y       float32
+	pivotX  float32
+	pivotY  float32
+	scale   float32
+	absorbs []DamageKind
+}
+
+// Cone and bucket soak shots but do nothing against explosions or a squash.
+// Their HP comes from zombies.json.
+var armorKinds = map[string]armorStats{
+	"cone":   {imageID: 5, y: -150, pivotX: 256, pivotY: 350, scale: 0.8, absorbs: []DamageKind{DamageProjectile}},
+	"bucket": {imageID: 6, y: -120, pivotX: 256, pivotY: 300, scale: 0.9, absorbs: []DamageKind{DamageProjectile}},
+}
+
+// armorBone is where armor sits on the zombie skeleton
+const armorBone = "hat"
+
+// wearArmor puts armor of the given kind on z, adding its bone to the head.
+func (z *Zombie) wearArmor(kind string, health float32) {
+	stats, ok := armorKinds[kind]
+	if !ok {
+		return
+	}
+	z.Armor = &Armor{
+		Kind:      kind,
+		Health:    health,
+		MaxHealth: health,
+		Bone:      armorBone,
+		Absorbs:   stats.absorbs,
+	}
+	z.Skeleton.AddBone("head", &Bone{
+		Name: armorBone, ImageID: stats.imageID,
+		LocalY: stats.y, PivotX: stats.pivotX, PivotY: stats.pivotY,
+		ScaleX: stats.scale, ScaleY: stats.scale,
+	})
+}
+
+// damageZombie applies damage to z, through its armor when the armor soaks
+// this kind. Damage left over after the armor breaks carries on to Health.
+func (w *World) damageZombie(z *Zombie, amount float32, kind DamageKind) {
+	if a := z.Armor; a != nil && slices.Contains(a.Absorbs, kind) {
+		soaked := min(amount, a.Health)
+		a.Health -= soaked
+		amount -= soaked
+		if a.Health <= 0 {
+			w.breakArmor(z)
+		}
+	}
+	z.Health -= amount
+	// Damage the armor doesn't soak (an explosion, a squash) can kill the
+	// zombie through it; the armor still comes off on its own rather than
+	// falling with the head
+	if z.Health <= 0 && z.Armor != nil {
+		w.breakArmor(z)
+	}
+	w.checkLimbs(z)
+}
+
+// breakArmor knocks z's armor off: its bone leaves the skeleton as debris.
+func (w *World) breakArmor(z *Zombie) {
+	a := z.Armor
+	z.Armor = nil
+
+	evt := zombieEvent(EventArmorDropped, z)
+	evt.Kind = a.Kind
+	if b := z.Skeleton.DetachBone(a.Bone); b != nil {
+		evt.X, evt.Y = b.WorldX, b.WorldY
+		evt.Target = w.spawnDebris(b, armorDropVX, armorDropVY, z.Y+debrisGroundOffset)
+	}
+	w.emit(evt)
+}
+
+// Armor pops up and back, away from the lawn
+const (
+	armorDropVX = 0.05
+	armorDropVY = -0.3
+)
@@ -0,0 +1,74 @@
+package sim
+
+import "testing"
+
+func TestArmorSoaksShots(t *testing.T) {
+	w := NewWorld()
+	z := zombieIn(t, w, "conehead", 2, 700)
+	cone := z.Armor.Health
+	w.PollEvents()
+
+	w.damageZombie(z, cone-1, DamageProjectile)
+	if z.Health != z.MaxHealth || z.Armor == nil || z.Armor.Health != 1 {
+		t.Fatalf("health %v, armor %+v: want the cone to take the whole hit", z.Health, z.Armor)
+	}
+
+	w.damageZombie(z, 11, DamageProjectile)
+	if z.Armor != nil {
+		t.Fatal("cone still on after its health ran out")
+	}
+	if got, want := z.Health, z.MaxHealth-10; got != want {
+		t.Errorf("health = %v, want %v with the overflow carried through", got, want)
+	}
+	if _, ok := z.Skeleton.Bones[armorBone]; ok {
+		t.Error("hat bone still on the skeleton")
+	}
+	drops := eventsOf(w.PollEvents(), EventArmorDropped)
+	if len(drops) != 1 || drops[0].Kind != "cone" {
+		t.Fatalf("armor_dropped events = %+v, want one for the cone", drops)
+	}
+	if _, ok := w.Debris[drops[0].Target]; !ok {
+		t.Errorf("dropped cone is not debris")
+	}
+}
+
+// Explosions and crushes ignore armor, but a zombie they kill still drops
+// it as its own debris before its head falls.
+func TestArmorDropsOnUnsoakedKill(t *testing.T) {
+	for _, kind := range []DamageKind{DamageExplosion, DamageCrush} {
+		t.Run(string(kind), func(t *testing.T) {
+			w := NewWorld()
+			z := zombieIn(t, w, "buckethead", 2, 700)
+			w.PollEvents()
+
+			w.damageZombie(z, z.MaxHealth, kind)
+			if z.Armor != nil {
+				t.Error("dead zombie still wears its armor")
+			}
+			evts := w.PollEvents()
+			drops := eventsOf(evts, EventArmorDropped)
+			if len(drops) != 1 || drops[0].Kind != "bucket" {
+				t.Fatalf("armor_dropped events = %+v, want one for the bucket", drops)
+			}
+			for _, e := range eventsOf(evts, EventLimbLost) {
+				if e.Kind != "head" {
+					continue
+				}
+				if _, ok := w.Skeletons[e.Target].Bones[armorBone]; ok {
+					t.Error("bucket fell with the head")
+				}
+			}
+		})
+	}
+}
+
+func TestCherryBombDropsArmor(t *testing.T) {
+	w := NewWorld()
+	plantIn(t, w, "cherrybomb", 2, 2)
+	z := zombieIn(t, w, "buckethead", 2, float32(w.Grid.StartX+2*w.Grid.CellSize))
+
+	runUntil(t, w, EventExplode, 300)
+	if z.Armor != nil || !z.Dying {
+		t.Errorf("armor %+v, dying %v: want the bucket off and the zombie dying", z.Armor, z.Dying)
+	}
+}
@@ -0,0 +1,82 @@
+package sim
+
+// The simulation always advances in fixed ticks so that frame hitches on one
+// machine can't change outcomes (e.g. which frame a potato mine arms on).
+const (
+	TickRate = 60
+	TickDT   = float32(1000) / TickRate // milliseconds per tick
+
+	// After a long stall (tab in background, debugger) we drop time rather
+	// than running hundreds of catch-up ticks in a single frame.
+	maxTicksPerAdvance = 10
+)
+
+// Clock accumulates real frame time and hands it out as whole ticks.
+type Clock struct {
+	Tick        uint64  // ticks simulated since the world was created
+	Accumulator float32 // leftover milliseconds not yet simulated
+}
+
+// AdvanceResult is the merged outcome of every tick run by Advance.
+type AdvanceResult struct {
+	StepResult
+	Ticks int     // ticks run this call
+	Alpha float32 // 0..1 progress into the next tick, for render interpolation
+
+	// Previous[i] is where Positions[i] stood before the latest tick, so the
+	// renderer can draw Previous + (Positions - Previous) * Alpha. Entities
+	// spawned by that tick have no earlier position and repeat the current one.
+	Previous []EntityPos
+}
+
+// Advance feeds frameDt milliseconds of real time into the clock and runs as
+// many fixed ticks as fit. Positions are current and Previous is one tick
+// behind; deaths and events are collected from every tick run.
+func (w *World) Advance(frameDt float32) AdvanceResult {
+	var res AdvanceResult
+
+	w.Clock.Accumulator += frameDt
+	if limit := TickDT * maxTicksPerAdvance; w.Clock.Accumulator > limit {
+		w.Clock.Accumulator = limit
+	}
+
+	for w.Clock.Accumulator >= TickDT {
+		w.Clock.Accumulator -= TickDT
+		if w.Clock.Accumulator < TickDT {
+			// Last tick of this call: keep where things were before it
+			w.lastPositions = w.appendPositions(w.lastPositions[:0])
+			for _, s := range w.Skeletons {
+				s.rememberTransforms()
+			}
+		}
+		step := w.Step(TickDT)
+
+		res.Deaths = append(res.Deaths, step.Deaths...)
+		// Step's events alias the world buffer, so copy them out
+		res.Events = append(res.Events, step.Events...)
+		res.Ticks++
+	}
+
+	// Reported even when no tick ran so the renderer always has positions
+	res.Positions = w.appendPositions(nil)
+	res.Previous = w.previousPositions(res.Positions)
+	res.Alpha = w.Clock.Accumulator / TickDT
+	return res
+}
+
+// previousPositions lines lastPositions up with cur by entity ID.
+func (w *World) previousPositions(cur []EntityPos) []EntityPos {
+	prev := make([]EntityPos, len(cur))
+	byID := make(map[int]EntityPos, len(w.lastPositions))
+	for _, p := range w.lastPositions {
+		byID[p.ID] = p
+	}
+	for i, p := range cur {
+		if last, ok := byID[p.ID]; ok {
+			prev[i] = last
+		} else {
+			prev[i] = p
+		}
+	}
+	return prev
+}
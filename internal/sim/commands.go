@@ -0,0 +1,148 @@
+package sim
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Player input reaches the simulation only as Commands. Each one is stamped
+// with the tick it applies on, which is what makes replays exact: feeding
+// the same commands on the same ticks into a world with the same seed gives
+// the same level.
+
+type CommandType string
+
+const (
+	CmdPlacePlant CommandType = "place_plant" // Row, Col, Kind = plant type
+	CmdShovel     CommandType = "shovel"      // Row, Col
+	CmdCollectSun CommandType = "collect_sun" // ID = sun
+	CmdSelectSeed CommandType = "select_seed" // Kind = plant type
+)
+
+type Command struct {
+	Tick uint64      `json:"tick"` // relative to the start of the recording
+	Type CommandType `json:"type"`
+	Row  int         `json:"row,omitempty"`
+	Col  int         `json:"col,omitempty"`
+	Kind string      `json:"kind,omitempty"`
+	ID   int         `json:"id,omitempty"`
+}
+
+// Submit queues live player input for the next tick. Input is refused while
+// a replay is playing so it can't diverge from the recording.
+func (w *World) Submit(cmd Command) error {
+	if w.playback != nil {
+		return fmt.Errorf("replay in progress, input ignored")
+	}
+	if err := w.checkCommand(cmd); err != nil {
+		return err
+	}
+	cmd.Tick = w.Clock.Tick
+	w.pending = append(w.pending, cmd)
+	return nil
+}
+
+// applyCommands runs at the start of every Step, before any entity updates.
+func (w *World) applyCommands() {
+	if w.playback != nil {
+		w.pending = append(w.pending, w.playback.due(w.Clock.Tick)...)
+	}
+
+	for _, cmd := range w.pending {
+		if w.recording != nil {
+			rec := cmd
+			rec.Tick = w.Clock.Tick - w.recording.startTick
+			w.recording.Commands = append(w.recording.Commands, rec)
+		}
+		w.applyCommand(cmd)
+	}
+	w.pending = w.pending[:0]
+}
+
+func (w *World) checkCommand(cmd Command) error {
+	switch cmd.Type {
+	case CmdPlacePlant, CmdShovel:
+		if cmd.Row < 0 || cmd.Row >= w.Grid.Rows || cmd.Col < 0 || cmd.Col >= w.Grid.Cols {
+			return fmt.Errorf("%s: cell %d,%d is off the lawn", cmd.Type, cmd.Row, cmd.Col)
+		}
+		if cmd.Type == CmdPlacePlant && cmd.Kind == "" {
+			return fmt.Errorf("%s: missing plant type", cmd.Type)
+		}
+		if _, ok := w.Registry.Plants[cmd.Kind]; cmd.Type == CmdPlacePlant && !ok {
+			return fmt.Errorf("%s: unknown plant type %q", cmd.Type, cmd.Kind)
+		}
+	case CmdCollectSun:
+		if cmd.ID <= 0 {
+			return fmt.Errorf("%s: missing sun id", cmd.Type)
+		}
+	case CmdSelectSeed:
+	default:
+		return fmt.Errorf("unknown command %q", cmd.Type)
+	}
+	return nil
+}
+
+// applyCommand carries out a validated command. Commands that are no longer
+// possible by the time they apply (cell filled, sun spent or the seed still
+// recharging in the meantime) are dropped silently; they are still recorded,
+// and drop the same way on playback.
+func (w *World) applyCommand(cmd Command) {
+	switch cmd.Type {
+	case CmdPlacePlant:
+		if !w.canPlace(cmd.Kind, cmd.Row, cmd.Col) {
+			return
+		}
+		cost := w.SeedStatus(cmd.Kind).Cost
+		if !w.spendOnSeed(cmd.Kind) {
+			return
+		}
+		x := float32(w.Grid.StartX + float64(cmd.Col)*w.Grid.CellSize)
+		y := float32(w.Grid.StartY + float64(cmd.Row)*w.Grid.CellSize)
+		p := w.CreatePlant(cmd.Kind, x, y)
+		evt := plantEvent(EventPlantPlaced, p)
+		evt.Amount = float32(cost)
+		w.emit(evt)
+	case CmdShovel:
+		if p := w.PlantAt(cmd.Row, cmd.Col); p != nil {
+			w.emit(plantEvent(EventPlantRemoved, p))
+			w.DestroyPlant(p.ID)
+		}
+	case CmdSelectSeed:
+		w.SelectedSeed = cmd.Kind
+	case CmdCollectSun:
+		w.collectSun(cmd.ID)
+	}
+}
+
+// PlantAt returns the topmost plant in a cell, or nil. A plant sitting on a
+// lily pad is returned instead of the pad.
+func (w *World) PlantAt(row, col int) *Plant {
+	var found *Plant
+	for _, id := range slices.Sorted(maps.Keys(w.Plants)) {
+		p := w.Plants[id]
+		if p.Row != row || p.Col != col {
+			continue
+		}
+		if found == nil || found.Type == "lily_pad" {
+			found = p
+		}
+	}
+	return found
+}
+
+func (w *World) canPlace(typ string, row, col int) bool {
+	if _, ok := w.Registry.Plants[typ]; !ok {
+		return false
+	}
+	existing := w.PlantAt(row, col)
+	if existing == nil {
+		return true
+	}
+	// Only a lily pad can carry another (non-aquatic) plant
+	return existing.Type == "lily_pad" && !isAquatic(typ)
+}
+
+func isAquatic(typ string) bool {
+	return typ == "lily_pad" || typ == "tangle_kelp"
+}
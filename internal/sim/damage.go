@@ -0,0 +1,48 @@
+package sim
+
+// DamageState is one wear tier of a tank plant. Once health drops below
+// Below (a fraction of max health) the plant switches to Skin and the bones
+// listed in Bones switch to the given ImageIDs.
+type DamageState struct {
+	Below float32        `json:"below"`
+	Skin  string         `json:"skin"`
+	Bones map[string]int `json:"bones,omitempty"` // bone name -> ImageID
+}
+
+// damagePlant takes health off p and moves it to a worse damage state when a
+// threshold is crossed. It does not remove the plant; callers handle death.
+func (w *World) damagePlant(p *Plant, amount float32) {
+	p.Health -= amount
+	if p.Health <= 0 || p.MaxHealth <= 0 {
+		return
+	}
+
+	states := w.Registry.Plants[p.Type].DamageStates
+	tier := p.DamageTier
+	for tier < len(states) && p.Health < states[tier].Below*p.MaxHealth {
+		tier++
+	}
+	if tier == p.DamageTier {
+		return
+	}
+
+	// Skipped tiers still apply in order so later ones can override earlier
+	// bone images
+	for _, ds := range states[p.DamageTier:tier] {
+		p.Skin = ds.Skin
+		if p.Skeleton == nil {
+			continue
+		}
+		for name, img := range ds.Bones {
+			if b, ok := p.Skeleton.Bones[name]; ok {
+				b.ImageID = img
+			}
+		}
+	}
+	p.DamageTier = tier
+
+	evt := plantEvent(EventPlantDamaged, p)
+	evt.Amount = float32(tier)
+	evt.Kind = p.Skin
+	w.emit(evt)
+}
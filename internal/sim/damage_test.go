@@ -0,0 +1,49 @@
+package sim
+
+import "testing"
+
+func TestDamageStates(t *testing.T) {
+	w := NewWorld()
+	p := plantIn(t, w, "wallnut", 2, 2)
+	w.PollEvents()
+
+	w.damagePlant(p, p.MaxHealth*0.2)
+	if p.DamageTier != 0 || len(w.PollEvents()) != 0 {
+		t.Fatalf("tier %d after a light hit, want no wear", p.DamageTier)
+	}
+
+	w.damagePlant(p, p.MaxHealth*0.2)
+	evts := w.PollEvents()
+	if len(evts) != 1 || evts[0].Type != EventPlantDamaged || evts[0].Amount != 1 || evts[0].Kind != "wallnut_cracked1" {
+		t.Fatalf("events = %+v, want one plant_damaged to tier 1", evts)
+	}
+	if p.Skin != "wallnut_cracked1" {
+		t.Errorf("skin = %q, want wallnut_cracked1", p.Skin)
+	}
+}
+
+func TestDamageStatesSkipTiers(t *testing.T) {
+	w := NewWorld()
+	// A copy of the stock registry whose wall-nut has a bone to swap
+	stats := w.Registry.Plants["wallnut"]
+	stats.DamageStates = []DamageState{
+		{Below: 0.66, Skin: "cracked1", Bones: map[string]int{"body": 8}},
+		{Below: 0.33, Skin: "cracked2", Bones: map[string]int{"body": 9}},
+	}
+	r := *w.Registry
+	r.Plants = map[string]PlantStats{"wallnut": stats}
+	w.Registry = &r
+
+	p := plantIn(t, w, "wallnut", 2, 2)
+	p.Skeleton.AddBone("", &Bone{Name: "body", ImageID: 7, ScaleX: 1, ScaleY: 1})
+	w.PollEvents()
+
+	w.damagePlant(p, p.MaxHealth*0.8)
+	evts := w.PollEvents()
+	if len(evts) != 1 || evts[0].Amount != 2 || evts[0].Kind != "cracked2" {
+		t.Fatalf("events = %+v, want one plant_damaged straight to tier 2", evts)
+	}
+	if img := p.Skeleton.Bones["body"].ImageID; img != 9 {
+		t.Errorf("body image = %d, want the tier 2 image 9", img)
+	}
+}
@@ -1,12 +1,13 @@
-package main
+package sim
 
 type Dave struct {
-	ID        int
-	X, Y      float32
-	TargetX   float32
-	Visible   bool
-	Skeleton  *Skeleton
-	AnimState *AnimationState
+	ID         int
+	X, Y       float32
+	TargetX    float32
+	Visible    bool
+	Skeleton   *Skeleton `json:"-"` // Restored from SkeletonID in snapshots
+	SkeletonID int
+	AnimState  *AnimationState
 }
 
 type AnimationState struct {
@@ -31,6 +32,16 @@ func NewDave(id int, x, y float32) *Dave {
 	return d
 }
 
+// Dave enters from off the left edge, as in CrazyDave.js.
+const daveStartX = -200
+
+// Appear shows Dave and starts his slide in to TargetX. Until then he is
+// neither updated nor drawn.
+func (d *Dave) Appear() {
+	d.Visible = true
+	d.X = daveStartX
+}
+
 func (d *Dave) Update(dt float32) {
 	if !d.Visible {
 		return
@@ -0,0 +1,83 @@
+package sim
+
+import "math"
+
+// Zombies lose an arm at half health and their head at zero, then collapse
+// over deathCollapseTime before they are removed. Limbs become debris.
+const (
+	armLossThreshold  = 0.5 // fraction of MaxHealth
+	deathCollapseTime = 1000
+)
+
+// Bones that come off, see newZombieSkeleton
+const (
+	lostArmBone  = "lArm"
+	lostHeadBone = "head"
+)
+
+// Launch velocities for knocked-off limbs, px per ms
+const (
+	armDropVX  = -0.03
+	armDropVY  = -0.15
+	headDropVX = 0.05
+	headDropVY = -0.25
+)
+
+// checkLimbs fires the health-threshold triggers. Damage that takes a zombie
+// from full to zero in one hit drops both the arm and the head.
+func (w *World) checkLimbs(z *Zombie) {
+	if !z.LostArm && z.Health <= z.MaxHealth*armLossThreshold {
+		z.LostArm = true
+		w.loseLimb(z, "arm", lostArmBone, armDropVX, armDropVY)
+	}
+	if z.Health <= 0 && !z.Dying {
+		w.loseLimb(z, "head", lostHeadBone, headDropVX, headDropVY)
+		w.startDying(z)
+	}
+}
+
+func (w *World) loseLimb(z *Zombie, limb, bone string, vx, vy float32) {
+	if z.Skeleton == nil {
+		return
+	}
+	b := z.Skeleton.DetachBone(bone)
+	if b == nil {
+		return
+	}
+	evt := zombieEvent(EventLimbLost, z)
+	evt.Kind = limb
+	evt.X, evt.Y = b.WorldX, b.WorldY
+	evt.Target = w.spawnDebris(b, vx, vy, z.Y+debrisGroundOffset)
+	w.emit(evt)
+}
+
+// startDying stops the zombie in its tracks and starts the collapse. It no
+// longer eats, moves or counts as a target.
+func (w *World) startDying(z *Zombie) {
+	if z.IsEating {
+		z.IsEating = false
+		z.TargetID = 0
+		w.emit(zombieEvent(EventZombieEatStop, z))
+	}
+	z.Dying = true
+	z.DeathTimer = deathCollapseTime
+
+	evt := zombieEvent(EventZombieDying, z)
+	evt.Amount = deathCollapseTime
+	w.emit(evt)
+}
+
+// updateDying plays the collapse and reports whether it has finished.
+func (z *Zombie) updateDying(dt float32) bool {
+	z.DeathTimer -= dt
+	t := 1 - max(z.DeathTimer, 0)/deathCollapseTime
+
+	// Topple backwards and sink to the floor
+	z.setRot("torso", t*math.Pi/2)
+	if z.Skeleton != nil {
+		z.Skeleton.X = z.X + 50
+		z.Skeleton.Y = z.Y + 70 + 20*t
+		z.Skeleton.Update(dt)
+	}
+	return z.DeathTimer <= 0
+}
@@ -0,0 +1,93 @@
+package sim
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestZombieLosesArmAtHalfHealth(t *testing.T) {
+	w := NewWorld()
+	z := zombieIn(t, w, "basic", 2, 900)
+	w.PollEvents()
+
+	w.damageZombie(z, z.MaxHealth*0.4, DamageProjectile)
+	if z.LostArm || len(eventsOf(w.PollEvents(), EventLimbLost)) != 0 {
+		t.Fatal("arm lost above half health")
+	}
+	w.damageZombie(z, z.MaxHealth*0.2, DamageProjectile)
+	lost := eventsOf(w.PollEvents(), EventLimbLost)
+	if len(lost) != 1 || lost[0].Kind != "arm" {
+		t.Fatalf("limb_lost events = %+v, want the arm", lost)
+	}
+	if _, ok := z.Skeleton.Bones[lostArmBone]; ok {
+		t.Error("arm bone still on the skeleton")
+	}
+	if _, ok := w.Debris[lost[0].Target]; !ok {
+		t.Error("lost arm is not debris")
+	}
+	if z.Dying {
+		t.Error("zombie dying at half health")
+	}
+}
+
+func TestZombieDeathSequence(t *testing.T) {
+	w := NewWorld()
+	z := zombieIn(t, w, "basic", 2, 900)
+	w.PollEvents()
+
+	// One hit from full drops both the arm and the head
+	w.damageZombie(z, z.MaxHealth, DamageExplosion)
+	evts := w.PollEvents()
+	var limbs []string
+	for _, e := range eventsOf(evts, EventLimbLost) {
+		limbs = append(limbs, e.Kind)
+	}
+	if !slices.Equal(limbs, []string{"arm", "head"}) {
+		t.Errorf("lost %v, want arm then head", limbs)
+	}
+	dying := eventsOf(evts, EventZombieDying)
+	if len(dying) != 1 || dying[0].Amount != deathCollapseTime {
+		t.Fatalf("zombie_dying events = %+v, want one lasting %dms", dying, deathCollapseTime)
+	}
+
+	x := z.X
+	var died []int
+	ticks := 0
+	for len(died) == 0 && ticks < 200 {
+		res := w.Advance(TickDT)
+		died = res.Deaths
+		ticks++
+		if len(died) == 0 && z.X != x {
+			t.Fatal("dying zombie kept walking")
+		}
+	}
+	if !slices.Equal(died, []int{z.ID}) {
+		t.Fatalf("deaths = %v, want [%d]", died, z.ID)
+	}
+	if want := deathCollapseTime * TickRate / 1000; ticks < want {
+		t.Errorf("removed after %d ticks, before the %d-tick collapse finished", ticks, want)
+	}
+	if _, ok := w.Zombies[z.ID]; ok {
+		t.Error("dead zombie still in the world")
+	}
+	if _, ok := w.Skeletons[z.SkeletonID]; ok {
+		t.Error("dead zombie's skeleton still registered")
+	}
+}
+
+func TestDebrisFallsAndIsRemoved(t *testing.T) {
+	w := NewWorld()
+	z := zombieIn(t, w, "basic", 2, 900)
+	w.damageZombie(z, z.MaxHealth, DamageExplosion)
+	if len(w.Debris) != 2 {
+		t.Fatalf("%d debris, want the arm and the head", len(w.Debris))
+	}
+
+	removed := 0
+	for range 600 {
+		removed += len(eventsOf(w.Advance(TickDT).Events, EventDebrisRemoved))
+	}
+	if removed != 2 || len(w.Debris) != 0 {
+		t.Errorf("%d debris removed, %d left; want both gone", removed, len(w.Debris))
+	}
+}
@@ -0,0 +1,95 @@
+package sim
+
+import (
+	"maps"
+	"slices"
+)
+
+// Debris is a body part or accessory knocked off a zombie. It keeps its bone
+// as the root of a free-standing skeleton, falls under gravity until it hits
+// the lane floor, rests there for a while and is then removed.
+type Debris struct {
+	SkeletonID int     `json:"skeletonID"`
+	VX         float32 `json:"vx"` // px per ms
+	VY         float32 `json:"vy"`
+	Spin       float32 `json:"spin"` // rad per ms
+	GroundY    float32 `json:"groundY"`
+	Life       float32 `json:"life"` // ms left
+}
+
+const (
+	debrisGravity = 0.0015 // px per ms^2
+	debrisSpin    = 0.01
+	debrisLife    = 2000
+
+	// Lane floor below a zombie's Y, where debris comes to rest
+	debrisGroundOffset = 90
+)
+
+// spawnDebris turns a detached bone into debris starting where the bone was
+// drawn last and returns its skeleton ID.
+func (w *World) spawnDebris(b *Bone, vx, vy, groundY float32) int {
+	s := NewSkeleton(b.WorldX, b.WorldY)
+	// Bake the old parents' transform into the new root
+	b.LocalX, b.LocalY = 0, 0
+	b.Rotation = b.WorldRot
+	b.ScaleX, b.ScaleY = b.WorldScaleX, b.WorldScaleY
+	s.Root = b
+	s.registerBones(b)
+	s.Update(0)
+
+	id := w.RegisterSkeleton(s)
+	spin := float32(debrisSpin)
+	if vx < 0 {
+		spin = -spin
+	}
+	w.Debris[id] = &Debris{
+		SkeletonID: id,
+		VX:         vx,
+		VY:         vy,
+		Spin:       spin,
+		GroundY:    groundY,
+		Life:       debrisLife,
+	}
+	return id
+}
+
+func (s *Skeleton) registerBones(b *Bone) {
+	s.Bones[b.Name] = b
+	for _, c := range b.Children {
+		s.registerBones(c)
+	}
+}
+
+// updateDebris moves debris skeletons. Their skeletons are free-standing, so
+// the regular skeleton pass updates the transforms afterwards.
+func (w *World) updateDebris(dt float32) {
+	for _, id := range slices.Sorted(maps.Keys(w.Debris)) {
+		d := w.Debris[id]
+		s, ok := w.Skeletons[id]
+		if !ok {
+			delete(w.Debris, id)
+			continue
+		}
+
+		if s.Y < d.GroundY {
+			d.VY += debrisGravity * dt
+			s.X += d.VX * dt
+			s.Y += d.VY * dt
+			if s.Root != nil {
+				s.Root.Rotation += d.Spin * dt
+			}
+			if s.Y >= d.GroundY {
+				s.Y = d.GroundY
+			}
+			continue
+		}
+
+		d.Life -= dt
+		if d.Life <= 0 {
+			w.DestroySkeleton(id)
+			delete(w.Debris, id)
+			w.emit(Event{Type: EventDebrisRemoved, ID: id, Row: -1, Col: -1})
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package sim
+
+import (
+	"maps"
+	"slices"
+)
+
+// Plant hitbox width, same as Entity.js
+const plantWidth = 80
+
+// resolveEating starts and stops zombies chewing on the plant in front of
+// them and applies bite damage. Runs once per tick, before zombies move, so a
+// zombie that just lost its plant walks on in the same tick.
+func (w *World) resolveEating(dt float32) {
+	for _, id := range slices.Sorted(maps.Keys(w.Zombies)) {
+		z := w.Zombies[id]
+		if z.Health <= 0 {
+			continue
+		}
+
+		target := w.eatTarget(z)
+		switch {
+		case target == nil && z.IsEating:
+			z.IsEating = false
+			z.TargetID = 0
+			w.emit(zombieEvent(EventZombieEatStop, z))
+			continue
+		case target == nil:
+			continue
+		case !z.IsEating || z.TargetID != target.ID:
+			z.IsEating = true
+			z.TargetID = target.ID
+			evt := zombieEvent(EventZombieEatStart, z)
+			evt.Target = target.ID
+			w.emit(evt)
+		}
+
+		w.damagePlant(target, z.biteDamage(dt))
+		if target.Health <= 0 {
+			evt := plantEvent(EventPlantEaten, target)
+			evt.Target = z.ID
+			w.emit(evt)
+			w.DestroyPlant(target.ID)
+			// Other zombies on the same plant notice next tick
+		}
+	}
+}
+
+// eatTarget is the plant a zombie is touching in its own lane. With several
+// (a plant on a lily pad) the one on top is eaten first.
+func (w *World) eatTarget(z *Zombie) *Plant {
+	var target *Plant
+	for _, id := range slices.Sorted(maps.Keys(w.Plants)) {
+		p := w.Plants[id]
+		if p.Row != z.Row || p.Health <= 0 || !p.grounded() {
+			continue
+		}
+		if z.X >= p.X+plantWidth || z.X+zombieWidth <= p.X {
+			continue
+		}
+		if target == nil || p.X > target.X || (p.X == target.X && target.Type == "lily_pad") {
+			target = p
+		}
+	}
+	return target
+}
+
+// biteDamage converts Damage, which zombies.json defines per 60 Hz frame
+// (Game.js applied it once per frame), into damage for dt milliseconds,
+// slowed by any status effects.
+func (z *Zombie) biteDamage(dt float32) float32 {
+	return z.Damage * z.modifiers().Eat * dt / TickDT
+}
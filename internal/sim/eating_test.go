@@ -0,0 +1,62 @@
+package sim
+
+import "testing"
+
+func TestZombieEatsPlant(t *testing.T) {
+	w := NewWorld()
+	p := plantIn(t, w, "sunflower", 2, 2)
+	z := zombieIn(t, w, "basic", 2, p.X+20)
+	x := z.X
+
+	start := runUntil(t, w, EventZombieEatStart, 1)[0]
+	if start.ID != z.ID || start.Target != p.ID {
+		t.Errorf("eat_start = %+v, want zombie %d on plant %d", start, z.ID, p.ID)
+	}
+	if got, want := p.Health, p.MaxHealth-z.Damage; got != want {
+		t.Errorf("plant health after one bite = %v, want %v", got, want)
+	}
+	w.Advance(TickDT)
+	if z.X != x {
+		t.Errorf("eating zombie moved from %v to %v", x, z.X)
+	}
+
+	p.Health = z.Damage / 2
+	eaten := runUntil(t, w, EventPlantEaten, 1)[0]
+	if eaten.ID != p.ID || eaten.Target != z.ID {
+		t.Errorf("plant_eaten = %+v, want plant %d by zombie %d", eaten, p.ID, z.ID)
+	}
+	if _, ok := w.Plants[p.ID]; ok {
+		t.Error("eaten plant still in the world")
+	}
+	runUntil(t, w, EventZombieEatStop, 1)
+	if z.IsEating || z.X >= x {
+		t.Errorf("eating %v at x %v: want the zombie walking on from %v", z.IsEating, z.X, x)
+	}
+}
+
+func TestZombieLeavesOtherLanesAlone(t *testing.T) {
+	w := NewWorld()
+	p := plantIn(t, w, "wallnut", 1, 2)
+	zombieIn(t, w, "basic", 2, p.X+20)
+	for range 60 {
+		w.Advance(TickDT)
+	}
+	if p.Health != p.MaxHealth {
+		t.Errorf("plant in another lane took %v damage", p.MaxHealth-p.Health)
+	}
+}
+
+func TestZombieEatsPlantOnLilyPadFirst(t *testing.T) {
+	w := NewWorld()
+	pad := plantIn(t, w, "lily_pad", 2, 2)
+	top := plantIn(t, w, "peashooter", 2, 2)
+	zombieIn(t, w, "basic", 2, pad.X+20)
+
+	start := runUntil(t, w, EventZombieEatStart, 1)[0]
+	if start.Target != top.ID {
+		t.Errorf("zombie ate plant %d, want the peashooter %d on the pad", start.Target, top.ID)
+	}
+	if pad.Health != pad.MaxHealth {
+		t.Error("lily pad bitten while a plant stands on it")
+	}
+}
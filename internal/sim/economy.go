@@ -0,0 +1,182 @@
+package sim
+
+import (
+	"maps"
+	"slices"
+)
+
+// The economy tracks the player's sun, the suns lying on the lawn waiting to
+// be clicked, the falling sky-sun schedule and per-seed recharge timers.
+// Costs and cooldowns come from plants.json, see Registry.
+
+// Sun and sky-sun defaults, matching the old Game.js and Sun.js
+const (
+	StartingSun       = 100
+	skySunInterval    = 10000 // ms
+	skySunStartY      = -50
+	sunValue          = 25
+	sunFallSpeed      = 0.05  // px per ms
+	sunLifetime       = 10000 // ms on the lawn before it fades away
+	zombieKillSun     = 10
+	sunflowerDropX    = 10
+	sunflowerDropY    = 10
+	sunflowerDropFall = 30 // how far a sunflower's sun drops before resting
+)
+
+type Economy struct {
+	Sun int `json:"sun"`
+
+	// Sky sun falls every SkySunInterval ms; 0 turns it off (night levels)
+	SkySunInterval float32 `json:"skySunInterval"`
+	SkySunTimer    float32 `json:"skySunTimer"`
+
+	// ms left before each seed can be planted again
+	Recharge map[string]float32 `json:"recharge"`
+}
+
+func newEconomy() Economy {
+	return Economy{
+		Sun:            StartingSun,
+		SkySunInterval: skySunInterval,
+		Recharge:       make(map[string]float32),
+	}
+}
+
+// Sun is a collectable sun on the lawn.
+type Sun struct {
+	ID      int     `json:"id"`
+	X       float32 `json:"x"`
+	Y       float32 `json:"y"`
+	TargetY float32 `json:"targetY"` // where it stops falling
+	Value   int     `json:"value"`
+	Life    float32 `json:"life"`   // ms since it appeared
+	Source  string  `json:"source"` // "sky" or the plant type that made it
+}
+
+// SetSun sets the balance, e.g. at level start. Emits sun_balance.
+func (w *World) SetSun(amount int) {
+	w.Economy.Sun = amount
+	w.emitBalance()
+}
+
+// SetSkySunInterval changes how often sky sun falls; 0 turns it off.
+func (w *World) SetSkySunInterval(ms float32) {
+	w.Economy.SkySunInterval = max(ms, 0)
+	w.Economy.SkySunTimer = 0
+}
+
+func (w *World) earnSun(amount int) {
+	w.Economy.Sun += amount
+	w.emitBalance()
+}
+
+func (w *World) emitBalance() {
+	w.emit(Event{Type: EventSunBalance, Row: -1, Col: -1, Amount: float32(w.Economy.Sun)})
+}
+
+// dropSun puts a collectable sun on the lawn at x,y that falls to targetY.
+func (w *World) dropSun(x, y, targetY float32, value int, source string) *Sun {
+	s := &Sun{
+		ID:      w.newEntityID(),
+		X:       x,
+		Y:       y,
+		TargetY: targetY,
+		Value:   value,
+		Source:  source,
+	}
+	w.Suns[s.ID] = s
+	w.emit(Event{Type: EventSunSpawned, ID: s.ID, Row: -1, Col: -1, X: x, Y: y, Amount: float32(value), Kind: source})
+	return s
+}
+
+// collectSun banks a sun. Suns that expired before the click are ignored.
+func (w *World) collectSun(id int) {
+	s, ok := w.Suns[id]
+	if !ok {
+		return
+	}
+	delete(w.Suns, id)
+	w.emit(Event{Type: EventSunCollected, ID: id, Row: -1, Col: -1, X: s.X, Y: s.Y, Amount: float32(s.Value), Kind: s.Source})
+	w.earnSun(s.Value)
+}
+
+// updateEconomy runs the sky-sun schedule, moves suns and ticks recharge
+// timers down.
+func (w *World) updateEconomy(dt float32) {
+	e := &w.Economy
+
+	if e.SkySunInterval > 0 {
+		e.SkySunTimer += dt
+		if e.SkySunTimer > e.SkySunInterval {
+			e.SkySunTimer = 0
+			x, targetY := w.RandomSkySun()
+			w.dropSun(x, skySunStartY, targetY, sunValue, "sky")
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(w.Suns)) {
+		s := w.Suns[id]
+		s.Life += dt
+		if s.Life > sunLifetime {
+			delete(w.Suns, id)
+			w.emit(Event{Type: EventSunExpired, ID: id, Row: -1, Col: -1, X: s.X, Y: s.Y})
+			continue
+		}
+		if s.Y < s.TargetY {
+			s.Y = min(s.Y+sunFallSpeed*dt, s.TargetY)
+		}
+	}
+
+	for _, kind := range slices.Sorted(maps.Keys(e.Recharge)) {
+		e.Recharge[kind] -= dt
+		if e.Recharge[kind] <= 0 {
+			delete(e.Recharge, kind)
+			w.emit(Event{Type: EventSeedReady, Row: -1, Col: -1, Kind: kind})
+		}
+	}
+}
+
+// SeedStatus is what the seed bar needs to draw a packet.
+type SeedStatus struct {
+	Cost       int     `json:"cost"`
+	Cooldown   float32 `json:"cooldown"` // full recharge time, ms
+	Recharge   float32 `json:"recharge"` // ms left, 0 when ready
+	Affordable bool    `json:"affordable"`
+	Ready      bool    `json:"ready"`
+}
+
+// SeedStatus reports whether kind can be planted right now. Placement only
+// checks these two things; the cell itself is checked when it applies. A
+// kind missing from the registry is never affordable or ready.
+func (w *World) SeedStatus(kind string) SeedStatus {
+	stats, ok := w.Registry.Plants[kind]
+	if !ok {
+		return SeedStatus{}
+	}
+	cost := int(stats.SunCost)
+	recharge := w.Economy.Recharge[kind]
+	return SeedStatus{
+		Cost:       cost,
+		Cooldown:   stats.Cooldown,
+		Recharge:   recharge,
+		Affordable: w.Economy.Sun >= cost,
+		Ready:      recharge <= 0,
+	}
+}
+
+// spendOnSeed pays for kind and starts its recharge. Returns false, changing
+// nothing, if the seed isn't affordable and ready.
+func (w *World) spendOnSeed(kind string) bool {
+	st := w.SeedStatus(kind)
+	if !st.Affordable || !st.Ready {
+		return false
+	}
+	if st.Cost != 0 {
+		w.Economy.Sun -= st.Cost
+		w.emitBalance()
+	}
+	if st.Cooldown > 0 {
+		w.Economy.Recharge[kind] = st.Cooldown
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package sim
+
+import "testing"
+
+func place(w *World, kind string, row, col int) {
+	w.Submit(Command{Type: CmdPlacePlant, Row: row, Col: col, Kind: kind})
+	w.Advance(TickDT)
+}
+
+func TestPlantingSpendsSunAndRecharges(t *testing.T) {
+	w := NewWorld()
+	w.SetSkySunInterval(0)
+	w.SetSun(300)
+	cost := w.SeedStatus("peashooter").Cost
+
+	place(w, "peashooter", 0, 0)
+	if got, want := w.Economy.Sun, 300-cost; got != want {
+		t.Fatalf("sun = %d, want %d after planting", got, want)
+	}
+	st := w.SeedStatus("peashooter")
+	if st.Ready || st.Recharge <= 0 {
+		t.Fatalf("seed status %+v, want it recharging", st)
+	}
+
+	place(w, "peashooter", 1, 0)
+	if w.PlantAt(1, 0) != nil || w.Economy.Sun != 300-cost {
+		t.Fatal("planted again while the seed was recharging")
+	}
+
+	ready := runUntil(t, w, EventSeedReady, int(st.Recharge/TickDT)+2)
+	if ready[0].Kind != "peashooter" {
+		t.Errorf("seed_ready for %q, want peashooter", ready[0].Kind)
+	}
+	place(w, "peashooter", 1, 0)
+	if w.PlantAt(1, 0) == nil {
+		t.Error("could not plant once the seed was ready")
+	}
+}
+
+func TestPlantingNeedsEnoughSun(t *testing.T) {
+	w := NewWorld()
+	w.SetSkySunInterval(0)
+	w.SetSun(w.SeedStatus("peashooter").Cost - 1)
+
+	place(w, "peashooter", 0, 0)
+	if w.PlantAt(0, 0) != nil {
+		t.Fatal("planted without enough sun")
+	}
+	if !w.SeedStatus("peashooter").Ready {
+		t.Error("a refused placement started the recharge")
+	}
+}
+
+func TestSkySunCollectAndExpire(t *testing.T) {
+	w := NewWorld()
+	w.SetSkySunInterval(1000)
+	w.SetSun(0)
+
+	first := runUntil(t, w, EventSunSpawned, 100)[0]
+	if first.Kind != "sky" || first.Amount != sunValue {
+		t.Errorf("sun_spawned = %+v, want %d sky sun", first, sunValue)
+	}
+	w.Submit(Command{Type: CmdCollectSun, ID: first.ID})
+	w.Advance(TickDT)
+	if w.Economy.Sun != sunValue {
+		t.Errorf("sun = %d after collecting, want %d", w.Economy.Sun, sunValue)
+	}
+	if _, ok := w.Suns[first.ID]; ok {
+		t.Error("collected sun still on the lawn")
+	}
+
+	second := runUntil(t, w, EventSunSpawned, 100)[0]
+	w.SetSkySunInterval(0)
+	expired := runUntil(t, w, EventSunExpired, sunLifetime*TickRate/1000+2)[0]
+	if expired.ID != second.ID {
+		t.Errorf("sun %d expired, want %d", expired.ID, second.ID)
+	}
+	if w.Economy.Sun != sunValue {
+		t.Errorf("an uncollected sun changed the balance to %d", w.Economy.Sun)
+	}
+}
+
+func TestKillEarnsSun(t *testing.T) {
+	w := NewWorld()
+	w.SetSkySunInterval(0)
+	w.SetSun(0)
+	z := zombieIn(t, w, "basic", 2, 900)
+	w.damageZombie(z, z.MaxHealth, DamageExplosion)
+
+	runUntil(t, w, EventZombieDied, 200)
+	if w.Economy.Sun != zombieKillSun {
+		t.Errorf("sun = %d after a kill, want %d", w.Economy.Sun, zombieKillSun)
+	}
+}
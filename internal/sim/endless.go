@@ -0,0 +1,195 @@
+package sim
+
+import "fmt"
+
+// Endless (survival) mode never runs out of waves: each one is generated
+// when it starts, from a point budget spent on whichever zombie types are
+// unlocked by then. Waves come in flags of WavesPerFlag; the last wave of
+// every flag is a huge wave with a multiplied budget. All of it is tuned
+// from public/data/endless.json.
+//
+// Waves are generated on the tick they start, so they draw on the world RNG
+// in tick order and replays reproduce them from the seed alone.
+
+type EndlessConfig struct {
+	WavesPerFlag int `json:"wavesPerFlag"` // a huge wave every this many waves
+
+	// Budget for wave n (1-based) in flag f (0-based):
+	// startBudget + budgetPerWave*(n-1) + budgetPerFlag*f, times
+	// hugeWaveMultiplier for huge waves
+	StartBudget        float32 `json:"startBudget"`
+	BudgetPerWave      float32 `json:"budgetPerWave"`
+	BudgetPerFlag      float32 `json:"budgetPerFlag"`
+	HugeWaveMultiplier float32 `json:"hugeWaveMultiplier"`
+
+	// ms between spawns and before each wave
+	SpawnDelay     float32 `json:"spawnDelay"`
+	HugeSpawnDelay float32 `json:"hugeSpawnDelay"`
+	WaveDelay      float32 `json:"waveDelay"`
+	HugeWaveDelay  float32 `json:"hugeWaveDelay"`
+
+	Zombies []EndlessZombie `json:"zombies"`
+}
+
+// EndlessZombie prices a zombie type. It can be picked from wave
+// UnlockWave on; Weight (1 when omitted) is its share of the random picks
+// among the types the remaining budget still affords, and 0 leaves the type
+// out.
+type EndlessZombie struct {
+	Type       string   `json:"type"`
+	Cost       float32  `json:"cost"`
+	UnlockWave int      `json:"unlockWave,omitempty"`
+	Weight     *float32 `json:"weight,omitempty"`
+}
+
+// ParseEndless reads endless.json. When zombies is given, every type must
+// be one of its keys.
+func ParseEndless(data []byte, zombies map[string]ZombieStats) (*EndlessConfig, FieldErrors) {
+	var cfg EndlessConfig
+	if errs := decodeStrict(data, &cfg); errs != nil {
+		return nil, errs
+	}
+	if errs := cfg.validate(zombies); errs != nil {
+		return nil, errs
+	}
+	return &cfg, nil
+}
+
+func (c *EndlessConfig) validate(zombies map[string]ZombieStats) FieldErrors {
+	var errs FieldErrors
+	if c.WavesPerFlag <= 0 {
+		errs.add("wavesPerFlag", "must be positive")
+	}
+	if c.HugeWaveMultiplier < 1 {
+		errs.add("hugeWaveMultiplier", "must be at least 1")
+	}
+	// Budgets never shrink, so affording a zombie in wave 1 means affording
+	// one in every wave
+	nonNegative := []struct {
+		name  string
+		value float32
+	}{
+		{"startBudget", c.StartBudget},
+		{"budgetPerWave", c.BudgetPerWave},
+		{"budgetPerFlag", c.BudgetPerFlag},
+		{"spawnDelay", c.SpawnDelay},
+		{"hugeSpawnDelay", c.HugeSpawnDelay},
+		{"waveDelay", c.WaveDelay},
+		{"hugeWaveDelay", c.HugeWaveDelay},
+	}
+	for _, f := range nonNegative {
+		if f.value < 0 {
+			errs.add(f.name, "must not be negative")
+		}
+	}
+
+	if len(c.Zombies) == 0 {
+		errs.add("zombies", "must not be empty")
+	}
+	affordable := false
+	for i, z := range c.Zombies {
+		path := index("zombies", i)
+		if z.Type == "" {
+			errs.add(path+".type", "missing")
+		} else if _, ok := zombies[z.Type]; zombies != nil && !ok {
+			errs.add(path+".type", "unknown zombie type %q", z.Type)
+		}
+		if z.Cost <= 0 {
+			errs.add(path+".cost", "must be positive")
+		}
+		if z.UnlockWave < 0 {
+			errs.add(path+".unlockWave", "must not be negative")
+		}
+		if z.Weight != nil && *z.Weight < 0 {
+			errs.add(path+".weight", "must not be negative")
+		}
+		if z.UnlockWave <= 1 && z.Cost > 0 && z.Cost <= c.StartBudget && z.weight() > 0 {
+			affordable = true
+		}
+	}
+	if len(c.Zombies) > 0 && !affordable {
+		errs.add("startBudget", "buys none of the zombies unlocked in wave 1")
+	}
+	return errs
+}
+
+// StartEndless replaces any running level with endless waves.
+func (w *World) StartEndless(cfg *EndlessConfig) error {
+	if errs := cfg.validate(w.Registry.Zombies); errs != nil {
+		return fmt.Errorf("endless: %w", errs)
+	}
+	w.Waves = &WaveManager{
+		Waves:      []Wave{},
+		State:      WaveStartDelay,
+		StartDelay: cfg.waveDelay(0),
+		Endless:    cfg,
+	}
+	return nil
+}
+
+// isHuge reports whether wave index (0-based) closes a flag.
+func (c *EndlessConfig) isHuge(index int) bool {
+	return (index+1)%c.WavesPerFlag == 0
+}
+
+func (c *EndlessConfig) waveDelay(index int) float32 {
+	if c.isHuge(index) {
+		return c.HugeWaveDelay
+	}
+	return c.WaveDelay
+}
+
+func (c *EndlessConfig) budget(index int) float32 {
+	flag := index / c.WavesPerFlag
+	b := c.StartBudget + c.BudgetPerWave*float32(index) + c.BudgetPerFlag*float32(flag)
+	if c.isHuge(index) {
+		b *= c.HugeWaveMultiplier
+	}
+	return b
+}
+
+// endlessWave builds wave index (0-based) by picking unlocked zombies at
+// random, weighted, until the budget can't buy any more.
+func (w *World) endlessWave(c *EndlessConfig, index int) Wave {
+	huge := c.isHuge(index)
+	delay := c.SpawnDelay
+	if huge {
+		delay = c.HugeSpawnDelay
+	}
+	wave := Wave{Spawns: []Spawn{}, StartDelay: c.waveDelay(index), IsFlag: huge}
+
+	remaining := c.budget(index)
+	for {
+		var picks []EndlessZombie
+		var total float32
+		for _, z := range c.Zombies {
+			if z.UnlockWave <= index+1 && z.Cost <= remaining && z.weight() > 0 {
+				picks = append(picks, z)
+				total += z.weight()
+			}
+		}
+		if len(picks) == 0 || total <= 0 {
+			break
+		}
+
+		r := w.Rand.Float32() * total
+		pick := picks[len(picks)-1]
+		for _, z := range picks {
+			if r < z.weight() {
+				pick = z
+				break
+			}
+			r -= z.weight()
+		}
+		wave.Spawns = append(wave.Spawns, Spawn{Type: pick.Type, Delay: delay})
+		remaining -= pick.Cost
+	}
+	return wave
+}
+
+func (z EndlessZombie) weight() float32 {
+	if z.Weight == nil {
+		return 1
+	}
+	return *z.Weight
+}
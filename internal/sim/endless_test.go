@@ -0,0 +1,128 @@
+package sim
+
+import "testing"
+
+func testEndless(zombies ...EndlessZombie) *EndlessConfig {
+	return &EndlessConfig{
+		WavesPerFlag:       5,
+		StartBudget:        2,
+		BudgetPerWave:      1,
+		BudgetPerFlag:      10,
+		HugeWaveMultiplier: 2,
+		SpawnDelay:         1000,
+		HugeSpawnDelay:     200,
+		WaveDelay:          3000,
+		HugeWaveDelay:      6000,
+		Zombies:            zombies,
+	}
+}
+
+func weightOf(w float32) *float32 { return &w }
+
+func TestEndlessBudget(t *testing.T) {
+	c := testEndless(EndlessZombie{Type: "basic", Cost: 1})
+	for _, tt := range []struct {
+		index int
+		want  float32
+		huge  bool
+	}{
+		{0, 2, false},
+		{3, 5, false},
+		{4, 12, true}, // (2 + 4) * 2
+		{5, 17, false},
+		{9, 42, true}, // (2 + 9 + 10) * 2
+	} {
+		if got := c.budget(tt.index); got != tt.want {
+			t.Errorf("budget(%d) = %v, want %v", tt.index, got, tt.want)
+		}
+		if got := c.isHuge(tt.index); got != tt.huge {
+			t.Errorf("isHuge(%d) = %v, want %v", tt.index, got, tt.huge)
+		}
+	}
+}
+
+func TestEndlessWaveSpendsBudget(t *testing.T) {
+	c := testEndless(
+		EndlessZombie{Type: "basic", Cost: 1},
+		EndlessZombie{Type: "buckethead", Cost: 4, UnlockWave: 3},
+		EndlessZombie{Type: "football", Cost: 2, Weight: weightOf(0)},
+	)
+	w := NewWorld()
+	w.SetSeed(3)
+	cost := map[string]float32{"basic": 1, "buckethead": 4}
+	for index := range 20 {
+		wave := w.endlessWave(c, index)
+		var spent float32
+		for _, s := range wave.Spawns {
+			if s.Type == "buckethead" && index+1 < 3 {
+				t.Errorf("wave %d: buckethead before its unlock wave", index+1)
+			}
+			if s.Type == "football" {
+				t.Fatalf("wave %d: picked a type with weight 0", index+1)
+			}
+			spent += cost[s.Type]
+		}
+		// Basic zombies cost 1, so nothing affordable may be left over
+		if budget := c.budget(index); spent > budget || budget-spent >= 1 {
+			t.Errorf("wave %d spent %v of %v", index+1, spent, budget)
+		}
+		if wave.IsFlag != c.isHuge(index) {
+			t.Errorf("wave %d: IsFlag = %v", index+1, wave.IsFlag)
+		}
+		wantDelay := c.SpawnDelay
+		if wave.IsFlag {
+			wantDelay = c.HugeSpawnDelay
+		}
+		if len(wave.Spawns) > 0 && wave.Spawns[0].Delay != wantDelay {
+			t.Errorf("wave %d: spawn delay %v, want %v", index+1, wave.Spawns[0].Delay, wantDelay)
+		}
+	}
+}
+
+func TestEndlessRejectsUnaffordableStart(t *testing.T) {
+	c := testEndless(
+		EndlessZombie{Type: "basic", Cost: 1, Weight: weightOf(0)},
+		EndlessZombie{Type: "boss", Cost: 40},
+	)
+	if errs := c.validate(DefaultRegistry().Zombies); len(errs) == 0 {
+		t.Fatal("accepted a config whose first wave can't buy anything")
+	}
+}
+
+func TestEndlessRunFlagsAndKeepsOneWave(t *testing.T) {
+	w := NewWorld()
+	w.SetSeed(11)
+	if err := w.StartEndless(testEndless(EndlessZombie{Type: "basic", Cost: 1})); err != nil {
+		t.Fatal(err)
+	}
+	var started, flags []int
+	for range 60 * 60 * 10 {
+		res := w.Advance(TickDT)
+		for _, e := range res.Events {
+			switch e.Type {
+			case EventWaveStarted:
+				started = append(started, int(e.Amount))
+			case EventFlagWave:
+				flags = append(flags, int(e.Amount))
+			}
+		}
+		// Clear the lawn at once so waves follow each other quickly
+		for id := range w.Zombies {
+			w.DestroyZombie(id)
+		}
+		if n := len(w.Waves.Waves); n > 1 {
+			t.Fatalf("endless mode holds %d waves", n)
+		}
+	}
+	if len(started) < 10 {
+		t.Fatalf("only %d waves started", len(started))
+	}
+	for _, n := range flags {
+		if n%5 != 0 {
+			t.Errorf("flag on wave %d, want every 5th", n)
+		}
+	}
+	if len(flags) != len(started)/5 {
+		t.Errorf("%d flags in %d waves", len(flags), len(started))
+	}
+}
@@ -0,0 +1,147 @@
+package sim
+
+// EventProtocolVersion is bumped whenever an event type is removed or a
+// field changes meaning. Adding a new event type does not require a bump;
+// consumers must ignore types they don't know.
+const EventProtocolVersion = 1
+
+type EventType string
+
+// Event types and the fields each one fills in. Row and Col are -1, never
+// zero, when the event has no lane or cell, since row 0 and column 0 are
+// real places on the lawn: zombie and projectile events have Col = -1, and
+// sun, seed, wave, level and debris events have both at -1. Plant, zombie
+// and projectile events always set Kind, to the entity's type unless the
+// entry says otherwise. Any other unlisted field is zero.
+const (
+	// A plant fired. ID/Row/Col/X/Y = plant, Kind = plant type,
+	// Amount = projectiles spawned (one per lane).
+	EventShoot EventType = "shoot"
+	// A sunflower produced sun (plays its animation; the pickup itself is a
+	// separate sun_spawned). ID/Row/Col/X/Y = plant, Amount = sun value.
+	EventSpawnSun EventType = "spawn_sun"
+	// A potato mine finished arming. ID/Row/Col/X/Y = plant.
+	EventArm EventType = "arm"
+	// An explosive plant went off and was removed. ID/Row/Col/X/Y = plant,
+	// Kind = plant type (cherrybomb: 3x3 cells, potatomine: its own cell),
+	// Amount = damage dealt to each zombie in range.
+	EventExplode EventType = "explode"
+	// Squash picked a target. ID/Row/Col/X/Y = squash, Target = zombie.
+	EventSquashLock EventType = "squash_lock"
+	// Squash left the ground. ID/Row/Col/Y = squash, X = landing x,
+	// Amount = jump duration in ms, Target = zombie.
+	EventSquashJump EventType = "squash_jump"
+	// Squash hit the lawn. ID/Row/X/Y = squash, Col = landing cell
+	// (-1 off the lawn), Amount = damage dealt to each zombie in the cell.
+	EventSquashLand EventType = "squash_land"
+	// Squash finished and was removed. ID/Row/Col = its original cell.
+	EventSquashDone EventType = "squash_done"
+	// A zombie lost a limb. ID/Row = zombie, X/Y = where the limb was,
+	// Kind = "arm" or "head", Target = debris skeleton ID.
+	EventLimbLost EventType = "limb_lost"
+	// A zombie's health ran out and it started to collapse. ID/Row/X/Y =
+	// zombie, Kind = zombie type, Amount = collapse duration in ms.
+	EventZombieDying EventType = "zombie_dying"
+	// A zombie finished its death sequence and was removed from the world.
+	// ID/Row/X/Y = zombie, Kind = zombie type.
+	EventZombieDied EventType = "zombie_died"
+	// A plant was eaten. ID/Row/Col/X/Y = plant, Kind = plant type, Target = zombie.
+	EventPlantEaten EventType = "plant_eaten"
+	// A plant's wear got worse. ID/Row/Col/X/Y = plant, Amount = damage tier
+	// (1 = first threshold crossed), Kind = skin for that tier.
+	EventPlantDamaged EventType = "plant_damaged"
+	// A zombie started chewing. ID/Row/X/Y = zombie, Target = plant.
+	EventZombieEatStart EventType = "zombie_eat_start"
+	// A zombie's plant is gone and it walks on. ID/Row/X/Y = zombie.
+	EventZombieEatStop EventType = "zombie_eat_stop"
+	// A status effect was applied or refreshed. ID/Row/X/Y = zombie,
+	// Kind = status kind, Amount = duration in ms.
+	EventStatusApplied EventType = "status_applied"
+	// A status effect ran out. ID/Row/X/Y = zombie, Kind = status kind.
+	EventStatusExpired EventType = "status_expired"
+	// A zombie's render tint changed. ID/Row/X/Y = zombie, Kind = tint
+	// ("" for none).
+	EventZombieTint EventType = "zombie_tint"
+	// A zombie's armor broke and fell off. ID/Row = zombie, X/Y = where the
+	// armor was, Kind = armor kind, Target = debris skeleton ID (0 if the
+	// zombie had no armor bone).
+	EventArmorDropped EventType = "armor_dropped"
+	// A debris skeleton finished and was destroyed. ID = skeleton.
+	EventDebrisRemoved EventType = "debris_removed"
+	// A collectable sun appeared. ID/X/Y = sun, Amount = value,
+	// Kind = "sky" or the plant type that dropped it.
+	EventSunSpawned EventType = "sun_spawned"
+	// A sun was clicked and banked. ID/X/Y = sun, Amount = value, Kind = source.
+	EventSunCollected EventType = "sun_collected"
+	// A sun faded away uncollected. ID/X/Y = sun.
+	EventSunExpired EventType = "sun_expired"
+	// The sun balance changed. Amount = new balance.
+	EventSunBalance EventType = "sun_balance"
+	// A seed finished recharging. Kind = plant type.
+	EventSeedReady EventType = "seed_ready"
+	// A wave began. Amount = wave number (1-based).
+	EventWaveStarted EventType = "wave_started"
+	// The wave that just started is a flag ("huge") wave. Amount = wave number.
+	EventFlagWave EventType = "flag_wave"
+	// The last wave was cleared.
+	EventLevelComplete EventType = "level_complete"
+	// A wave spawned a zombie. ID/Row/X/Y = zombie, Kind = zombie type.
+	EventZombieSpawned EventType = "zombie_spawned"
+	// A place_plant command succeeded. ID/Row/Col/X/Y = new plant, Kind = plant type,
+	// Amount = sun paid.
+	EventPlantPlaced EventType = "plant_placed"
+	// A plant was shovelled. ID/Row/Col/X/Y = plant, Kind = plant type.
+	EventPlantRemoved EventType = "plant_removed"
+	// A projectile was fired. ID/Row/X/Y = projectile, Kind = plant type that fired it.
+	EventProjectileSpawned EventType = "projectile_spawned"
+	// A projectile hit a zombie and was removed. ID/Row/X/Y = projectile,
+	// Target = zombie, Amount = damage dealt, Kind = plant type.
+	EventProjectileHit EventType = "projectile_hit"
+	// A projectile left the lawn without hitting anything. ID/Row/X/Y =
+	// projectile, Kind = plant type.
+	EventProjectileExpired EventType = "projectile_expired"
+)
+
+// Event is the single, fixed-shape record passed to renderers. Keeping one
+// flat struct (rather than a free-form payload) means the wire format is the
+// same for every type and bridges never need type assertions.
+//
+// Wire format (JS object / JSON):
+//
+//	{type, id, row, col, x, y, amount, kind, target}
+type Event struct {
+	Type   EventType `json:"type"`
+	ID     int       `json:"id"`
+	Row    int       `json:"row"`
+	Col    int       `json:"col"`
+	X      float32   `json:"x"`
+	Y      float32   `json:"y"`
+	Amount float32   `json:"amount"`
+	Kind   string    `json:"kind"`
+	Target int       `json:"target"` // other entity involved, e.g. the zombie a projectile hit
+}
+
+func plantEvent(typ EventType, p *Plant) Event {
+	return Event{Type: typ, ID: p.ID, Row: p.Row, Col: p.Col, X: p.X, Y: p.Y, Kind: p.Type}
+}
+
+func zombieEvent(typ EventType, z *Zombie) Event {
+	return Event{Type: typ, ID: z.ID, Row: z.Row, Col: -1, X: z.X, Y: z.Y, Kind: z.Type}
+}
+
+func projectileEvent(typ EventType, pr *Projectile) Event {
+	return Event{Type: typ, ID: pr.ID, Row: pr.Row, Col: -1, X: pr.X, Y: pr.Y, Kind: pr.Kind}
+}
+
+func (w *World) emit(evt Event) {
+	w.events = append(w.events, evt)
+}
+
+// PollEvents returns the events emitted since the last call and clears the
+// buffer. The returned slice is only valid until the next update.
+func (w *World) PollEvents() []Event {
+	evts := w.events
+	// Reuse buffer capacity
+	w.events = w.events[:0]
+	return evts
+}
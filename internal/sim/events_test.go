@@ -0,0 +1,47 @@
+package sim
+
+import "testing"
+
+func TestEventSentinels(t *testing.T) {
+	noPlace := map[EventType]bool{
+		EventSunSpawned: true, EventSunCollected: true, EventSunExpired: true,
+		EventSunBalance: true, EventSeedReady: true, EventWaveStarted: true,
+		EventFlagWave: true, EventLevelComplete: true, EventDebrisRemoved: true,
+	}
+	noCol := map[EventType]bool{
+		EventZombieSpawned: true, EventZombieEatStart: true, EventZombieEatStop: true,
+		EventZombieDying: true, EventZombieDied: true, EventZombieTint: true,
+		EventStatusApplied: true, EventStatusExpired: true,
+		EventProjectileSpawned: true, EventProjectileHit: true, EventProjectileExpired: true,
+	}
+
+	w := NewWorld()
+	w.SetSeed(3)
+	w.SetSkySunInterval(1000)
+	if err := w.StartLevel(ProceduralWaves(1)); err != nil {
+		t.Fatal(err)
+	}
+	w.SetSun(1000)
+	if err := w.Submit(Command{Type: CmdPlacePlant, Row: 0, Col: 0, Kind: "snowpea"}); err != nil {
+		t.Fatal(err)
+	}
+	seen := map[EventType]bool{}
+	for range 6000 {
+		for _, e := range w.Advance(TickDT).Events {
+			seen[e.Type] = true
+			switch {
+			case noPlace[e.Type] && (e.Row != -1 || e.Col != -1):
+				t.Errorf("%s at row %d col %d, want -1/-1", e.Type, e.Row, e.Col)
+			case noCol[e.Type] && e.Col != -1:
+				t.Errorf("%s at col %d, want -1", e.Type, e.Col)
+			case noCol[e.Type] && e.Type != EventZombieTint && e.Kind == "":
+				t.Errorf("%s has no kind", e.Type)
+			}
+		}
+	}
+	for _, typ := range []EventType{EventSunSpawned, EventWaveStarted, EventZombieSpawned, EventProjectileSpawned} {
+		if !seen[typ] {
+			t.Errorf("no %s event to check", typ)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package sim
+
+import (
+	"maps"
+	"slices"
+)
+
+// explode blows up p, damaging every zombie whose hitbox reaches into the
+// cells within radius of p's cell (radius 1 is a 3x3 block, 0 the cell
+// itself), then removes p. Zombies killed here die in the zombie pass of the
+// same Step like any other.
+func (w *World) explode(p *Plant, radius int) {
+	g := w.Grid
+	left := float32(g.StartX + float64(p.Col-radius)*g.CellSize)
+	right := float32(g.StartX + float64(p.Col+radius+1)*g.CellSize)
+
+	for _, id := range slices.Sorted(maps.Keys(w.Zombies)) {
+		z := w.Zombies[id]
+		if z.Health <= 0 || z.Row < p.Row-radius || z.Row > p.Row+radius {
+			continue
+		}
+		if z.X >= right || z.X+zombieWidth <= left {
+			continue
+		}
+		w.damageZombie(z, p.Damage, DamageExplosion)
+	}
+
+	evt := plantEvent(EventExplode, p)
+	evt.Amount = p.Damage
+	w.emit(evt)
+	w.DestroyPlant(p.ID)
+}
+
+// mineTriggered reports whether a zombie is standing on an armed mine.
+func (w *World) mineTriggered(p *Plant) bool {
+	for _, id := range slices.Sorted(maps.Keys(w.Zombies)) {
+		z := w.Zombies[id]
+		if z.Row != p.Row || z.Health <= 0 {
+			continue
+		}
+		if z.X < p.X+plantWidth && z.X+zombieWidth > p.X {
+			return true
+		}
+	}
+	return false
+}
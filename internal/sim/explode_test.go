@@ -0,0 +1,72 @@
+package sim
+
+import "testing"
+
+func TestCherryBombHits3x3(t *testing.T) {
+	w := NewWorld()
+	p := plantIn(t, w, "cherrybomb", 2, 4)
+	cell := float32(w.Grid.CellSize)
+	inRange := []*Zombie{
+		zombieIn(t, w, "basic", 1, p.X-cell),
+		zombieIn(t, w, "basic", 2, p.X+10),
+		zombieIn(t, w, "basic", 3, p.X+cell),
+	}
+	outOfRange := []*Zombie{
+		zombieIn(t, w, "basic", 0, p.X),
+		zombieIn(t, w, "basic", 2, p.X+3*cell),
+	}
+
+	evt := runUntil(t, w, EventExplode, 300)[0]
+	if evt.ID != p.ID || evt.Amount != p.Damage {
+		t.Errorf("explode = %+v, want plant %d dealing %v", evt, p.ID, p.Damage)
+	}
+	if _, ok := w.Plants[p.ID]; ok {
+		t.Error("cherry bomb still planted after exploding")
+	}
+	for _, z := range inRange {
+		if z.Health > 0 {
+			t.Errorf("zombie in lane %d at x %v survived the blast", z.Row, z.X)
+		}
+	}
+	for _, z := range outOfRange {
+		if z.Health != z.MaxHealth {
+			t.Errorf("zombie in lane %d at x %v was hit from outside the blast", z.Row, z.X)
+		}
+	}
+}
+
+func TestPotatoMineArmsThenTriggers(t *testing.T) {
+	w := NewWorld()
+	p := plantIn(t, w, "potatomine", 2, 3)
+
+	// Walking over an unarmed mine does nothing but chew on it
+	early := zombieIn(t, w, "basic", 2, p.X+10)
+	for range 10 {
+		w.Advance(TickDT)
+	}
+	if p.IsArmed || early.Health != early.MaxHealth {
+		t.Fatal("mine went off before it was armed")
+	}
+	w.DestroyZombie(early.ID)
+
+	runUntil(t, w, EventArm, 2000)
+	if !p.IsArmed {
+		t.Fatal("arm event without the mine armed")
+	}
+	for range 10 {
+		w.Advance(TickDT)
+	}
+	if _, ok := w.Plants[p.ID]; !ok {
+		t.Fatal("armed mine went off with nobody on it")
+	}
+
+	victim := zombieIn(t, w, "basic", 2, p.X+10)
+	bystander := zombieIn(t, w, "basic", 2, p.X+float32(w.Grid.CellSize)+10)
+	runUntil(t, w, EventExplode, 1)
+	if victim.Health > 0 {
+		t.Error("zombie on the mine survived")
+	}
+	if bystander.Health != bystander.MaxHealth {
+		t.Error("mine hit a zombie in the next cell")
+	}
+}
@@ -0,0 +1,55 @@
+package sim
+
+type Grid struct {
+	Rows     int
+	Cols     int
+	CellSize float64
+	StartX   float64
+	StartY   float64
+
+	HoverRow int
+	HoverCol int
+}
+
+// DefaultRows is the day lawn; pool levels set their own, see Level.Rows.
+const DefaultRows = 5
+
+// NewGrid returns the default 5x9 day lawn layout.
+func NewGrid() *Grid {
+	return &Grid{
+		Rows:     DefaultRows,
+		Cols:     9,
+		CellSize: 100,
+		StartX:   245, // Adjusted from 200
+		StartY:   80,  // Adjusted from 100
+		HoverRow: -1,
+		HoverCol: -1,
+	}
+}
+
+func (g *Grid) Init(rows, cols int, cellSize, startX, startY float64) {
+	g.Rows = rows
+	g.Cols = cols
+	g.CellSize = cellSize
+	g.StartX = startX
+	g.StartY = startY
+}
+
+// CellAt maps a screen position to a lawn cell, or (-1, -1) when outside.
+func (g *Grid) CellAt(x, y float64) (int, int) {
+	if x < g.StartX || x >= g.StartX+float64(g.Cols)*g.CellSize ||
+		y < g.StartY || y >= g.StartY+float64(g.Rows)*g.CellSize {
+		return -1, -1
+	}
+
+	col := int((x - g.StartX) / g.CellSize)
+	row := int((y - g.StartY) / g.CellSize)
+	return row, col
+}
+
+func (g *Grid) CheckHover(x, y float64) (int, int) {
+	row, col := g.CellAt(x, y)
+	g.HoverRow = row
+	g.HoverCol = col
+	return row, col
+}
@@ -0,0 +1,55 @@
+package sim
+
+import "testing"
+
+// plantIn creates a plant of typ in the given cell.
+func plantIn(t *testing.T, w *World, typ string, row, col int) *Plant {
+	t.Helper()
+	x := float32(w.Grid.StartX + float64(col)*w.Grid.CellSize)
+	y := float32(w.Grid.StartY + float64(row)*w.Grid.CellSize)
+	p := w.CreatePlant(typ, x, y)
+	if p == nil {
+		t.Fatalf("no plant type %q", typ)
+	}
+	return p
+}
+
+// zombieIn creates a zombie of typ in lane row at x.
+func zombieIn(t *testing.T, w *World, typ string, row int, x float32) *Zombie {
+	t.Helper()
+	z := w.CreateZombie(typ, x, float32(w.Grid.StartY+float64(row)*w.Grid.CellSize))
+	if z == nil {
+		t.Fatalf("no zombie type %q", typ)
+	}
+	return z
+}
+
+// runUntil advances w a tick at a time until it emits an event of typ, and
+// returns every event of that type from that tick.
+func runUntil(t *testing.T, w *World, typ EventType, maxTicks int) []Event {
+	t.Helper()
+	for range maxTicks {
+		var found []Event
+		for _, e := range w.Advance(TickDT).Events {
+			if e.Type == typ {
+				found = append(found, e)
+			}
+		}
+		if len(found) > 0 {
+			return found
+		}
+	}
+	t.Fatalf("no %s event in %d ticks", typ, maxTicks)
+	return nil
+}
+
+// eventsOf returns the events of typ in evts, in order.
+func eventsOf(evts []Event, typ EventType) []Event {
+	var out []Event
+	for _, e := range evts {
+		if e.Type == typ {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,238 @@
+package sim
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// LevelSchemaVersion is the current level file format:
+//
+//	{"version": 1, "levels": [{id, type, rows, laneTypes, hasFog, waves}]}
+//
+// ParseLevels also reads the two older shapes, both bare arrays:
+//
+//   - levels.json: [{id, zombiesToSpawn, zombieTypes, spawnInterval}]
+//   - LevelConfig.js: [{id, type, rows, laneTypes, hasFog, waves}]
+//
+// cmd/pvz-levels rewrites old files into the current format.
+const LevelSchemaVersion = 1
+
+type LevelFile struct {
+	Version int     `json:"version"`
+	Levels  []Level `json:"levels"`
+}
+
+type Level struct {
+	ID        int      `json:"id"`
+	Type      string   `json:"type,omitempty"`      // "day", "pool", ...
+	Rows      int      `json:"rows,omitempty"`      // 0 means DefaultRows
+	LaneTypes []string `json:"laneTypes,omitempty"` // "grass" or "water", one per row
+	HasFog    bool     `json:"hasFog,omitempty"`
+	Waves     []Wave   `json:"waves"`
+}
+
+// Old levels.json entries are turned into waves of at most legacyWaveSize
+// zombies, cycling through zombieTypes in order, the last wave a flag wave.
+const legacyWaveSize = 10
+
+// legacyLevel is the union of both old shapes.
+type legacyLevel struct {
+	Level
+	ZombiesToSpawn *int     `json:"zombiesToSpawn"`
+	ZombieTypes    []string `json:"zombieTypes"`
+	SpawnInterval  float32  `json:"spawnInterval"`
+}
+
+// ParseLevels reads a level file in any supported shape and returns the
+// levels in the current schema, validated against the zombie types in
+// zombies. The error is a FieldErrors; for the old shapes its paths point
+// into the migrated levels.
+func ParseLevels(data []byte, zombies map[string]ZombieStats) (*LevelFile, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '[' {
+		file, errs := ValidateLevels(data, zombies)
+		if errs != nil {
+			return nil, errs
+		}
+		return file, nil
+	}
+
+	var old []legacyLevel
+	if errs := decodeStrict(data, &old); errs != nil {
+		return nil, errs
+	}
+	file := &LevelFile{Version: LevelSchemaVersion}
+	var errs FieldErrors
+	for i, l := range old {
+		lvl, e := l.migrate()
+		errs = append(errs, e.under(index("", i))...)
+		file.Levels = append(file.Levels, lvl)
+	}
+	if errs == nil {
+		errs = file.validate(zombies)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return file, nil
+}
+
+// ValidateLevels checks a level file in the current schema. When zombies is
+// given, every spawn must name one of its types.
+func ValidateLevels(data []byte, zombies map[string]ZombieStats) (*LevelFile, FieldErrors) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, FieldErrors{{Message: "empty file"}}
+	}
+	if data[0] == '[' {
+		return nil, FieldErrors{{Message: "old level format, run cmd/pvz-levels to migrate"}}
+	}
+	var file LevelFile
+	if errs := decodeStrict(data, &file); errs != nil {
+		return nil, errs
+	}
+	if file.Version != LevelSchemaVersion {
+		return nil, FieldErrors{{Path: "version", Message: fmt.Sprintf("must be %d, run cmd/pvz-levels to migrate", LevelSchemaVersion)}}
+	}
+	if errs := file.validate(zombies); errs != nil {
+		return nil, errs
+	}
+	return &file, nil
+}
+
+func (l legacyLevel) migrate() (Level, FieldErrors) {
+	lvl := l.Level
+	if l.Waves != nil {
+		// LevelConfig.js shape, already wave based
+		return lvl, nil
+	}
+	var errs FieldErrors
+	if l.ZombiesToSpawn == nil {
+		errs.add("", "has neither waves nor zombiesToSpawn")
+		return lvl, errs
+	}
+	if *l.ZombiesToSpawn < 0 {
+		errs.add("zombiesToSpawn", "must not be negative")
+	}
+	if len(l.ZombieTypes) == 0 {
+		errs.add("zombieTypes", "must not be empty")
+	}
+	if l.SpawnInterval < 0 {
+		errs.add("spawnInterval", "must not be negative")
+	}
+	if errs != nil {
+		return lvl, errs
+	}
+
+	total := *l.ZombiesToSpawn
+	for spawned := 0; spawned < total; {
+		wave := Wave{StartDelay: nextWaveDelay}
+		if spawned == 0 {
+			wave.StartDelay = firstWaveDelay
+		}
+		for len(wave.Spawns) < legacyWaveSize && spawned < total {
+			typ := l.ZombieTypes[spawned%len(l.ZombieTypes)]
+			wave.Spawns = append(wave.Spawns, Spawn{Type: typ, Delay: l.SpawnInterval})
+			spawned++
+		}
+		lvl.Waves = append(lvl.Waves, wave)
+	}
+	if n := len(lvl.Waves); n > 0 {
+		lvl.Waves[n-1].IsFlag = true
+	}
+	return lvl, nil
+}
+
+func (f *LevelFile) validate(zombies map[string]ZombieStats) FieldErrors {
+	var errs FieldErrors
+	seen := make(map[int]bool)
+	for i, l := range f.Levels {
+		path := index("levels", i)
+		if l.ID <= 0 {
+			errs.add(path+".id", "must be positive")
+		} else if seen[l.ID] {
+			errs.add(path+".id", "duplicate id %d", l.ID)
+		}
+		seen[l.ID] = true
+
+		if l.Rows < 0 {
+			errs.add(path+".rows", "must not be negative")
+		}
+		if len(l.LaneTypes) > 0 {
+			rows := l.Rows
+			if rows == 0 {
+				rows = DefaultRows
+			}
+			if len(l.LaneTypes) != rows {
+				errs.add(path+".laneTypes", "has %d lanes for %d rows", len(l.LaneTypes), rows)
+			}
+			for j, lt := range l.LaneTypes {
+				if lt != "grass" && lt != "water" {
+					errs.add(index(path+".laneTypes", j), "unknown lane type %q", lt)
+				}
+			}
+		}
+
+		if len(l.Waves) == 0 {
+			errs.add(path+".waves", "must not be empty")
+		}
+		for j, wave := range l.Waves {
+			wpath := index(path+".waves", j)
+			if wave.StartDelay < 0 {
+				errs.add(wpath+".startDelay", "must not be negative")
+			}
+			if len(wave.Spawns) == 0 {
+				errs.add(wpath+".spawns", "must not be empty")
+			}
+			for k, s := range wave.Spawns {
+				spath := index(wpath+".spawns", k)
+				if s.Type == "" {
+					errs.add(spath+".type", "missing")
+				} else if _, ok := zombies[s.Type]; zombies != nil && !ok {
+					errs.add(spath+".type", "unknown zombie type %q", s.Type)
+				}
+				if s.Delay < 0 {
+					errs.add(spath+".delay", "must not be negative")
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func checkSpawns(spawns []Spawn) error {
+	for i, s := range spawns {
+		if s.Type == "" {
+			return fmt.Errorf("spawn %d: missing zombie type", i)
+		}
+		if s.Delay < 0 {
+			return fmt.Errorf("spawn %d: negative delay", i)
+		}
+	}
+	return nil
+}
+
+// Level returns the level with the given id, or nil.
+func (f *LevelFile) Level(id int) *Level {
+	for i := range f.Levels {
+		if f.Levels[i].ID == id {
+			return &f.Levels[i]
+		}
+	}
+	return nil
+}
+
+// Marshal writes the file in the current schema.
+func (f *LevelFile) Marshal() ([]byte, error) {
+	return json.MarshalIndent(f, "", "    ")
+}
+
+// LoadLevel sizes the grid for l and starts its waves.
+func (w *World) LoadLevel(l *Level) error {
+	w.Grid.Rows = DefaultRows
+	if l.Rows > 0 {
+		w.Grid.Rows = l.Rows
+	}
+	return w.StartLevel(l.Waves)
+}
@@ -0,0 +1,65 @@
+package sim
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLevelsMigratesLegacy(t *testing.T) {
+	data := []byte(`[{"id": 3, "zombiesToSpawn": 12, "zombieTypes": ["basic", "conehead"], "spawnInterval": 1500}]`)
+	file, err := ParseLevels(data, DefaultRegistry().Zombies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Version != LevelSchemaVersion {
+		t.Errorf("version = %d, want %d", file.Version, LevelSchemaVersion)
+	}
+	lvl := file.Level(3)
+	if lvl == nil {
+		t.Fatal("level 3 missing after migration")
+	}
+	if len(lvl.Waves) != 2 || len(lvl.Waves[0].Spawns) != legacyWaveSize || len(lvl.Waves[1].Spawns) != 2 {
+		t.Fatalf("waves = %+v, want %d spawns then 2", lvl.Waves, legacyWaveSize)
+	}
+	if lvl.Waves[0].IsFlag || !lvl.Waves[1].IsFlag {
+		t.Error("only the last wave should be a flag wave")
+	}
+	if got := lvl.Waves[0].Spawns[1]; got.Type != "conehead" || got.Delay != 1500 {
+		t.Errorf("second spawn = %+v, want a conehead after 1500ms", got)
+	}
+}
+
+func TestParseLevelsRejectsUnknownZombies(t *testing.T) {
+	for name, data := range map[string]string{
+		"legacy":  `[{"id": 1, "zombiesToSpawn": 1, "zombieTypes": ["ghost"], "spawnInterval": 1000}]`,
+		"current": `{"version": 1, "levels": [{"id": 1, "waves": [{"spawns": [{"type": "ghost", "delay": 0}]}]}]}`,
+	} {
+		_, err := ParseLevels([]byte(data), DefaultRegistry().Zombies)
+		if err == nil || !strings.Contains(err.Error(), `unknown zombie type "ghost"`) {
+			t.Errorf("%s: err = %v, want the ghost reported", name, err)
+		}
+	}
+}
+
+func TestValidateLevelsWantsMigration(t *testing.T) {
+	_, errs := ValidateLevels([]byte(`[{"id": 1, "zombiesToSpawn": 1, "zombieTypes": ["basic"]}]`), nil)
+	if len(errs) != 1 || !strings.Contains(errs[0].Message, "cmd/pvz-levels") {
+		t.Errorf("errs = %v, want a pointer to cmd/pvz-levels", errs)
+	}
+}
+
+func TestLoadLevelResetsRows(t *testing.T) {
+	w := NewWorld()
+	if err := w.LoadLevel(&Level{ID: 1, Rows: 6}); err != nil {
+		t.Fatal(err)
+	}
+	if w.Grid.Rows != 6 {
+		t.Fatalf("rows = %d after a 6-row level", w.Grid.Rows)
+	}
+	if err := w.LoadLevel(&Level{ID: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if w.Grid.Rows != DefaultRows {
+		t.Errorf("rows = %d, want the default %d for a level without rows", w.Grid.Rows, DefaultRows)
+	}
+}
@@ -0,0 +1,147 @@
+package sim
+
+type Plant struct {
+	ID            int
+	Type          string
+	X, Y          float32
+	Row, Col      int // Lawn cell, resolved from X/Y on creation
+	Timer         float32
+	ShootInterval float32
+	Damage        float32 // per projectile, from plants.json "damage"
+	Lanes         []int   // lanes fired into, relative to Row; nil for non-shooters
+	Health        float32
+	MaxHealth     float32
+	DamageTier    int    // wear tiers passed, see damage.go
+	Skin          string // image set for the current tier, "" for the default
+
+	// Shooters get a "head" bone to fire from (see muzzle); damage states
+	// swap the images of any bones present
+	Skeleton   *Skeleton `json:"-"` // Restored from SkeletonID in snapshots
+	SkeletonID int
+
+	// State for logic
+	ShotsFired int
+	BurstTimer float32
+	IsArmed    bool // Potato Mine
+	SunValue   int  // Sunflower, per drop
+
+	// Staged attacks (squash), see squash.go
+	AnimState  string
+	StateTimer float32
+	TargetID   int
+	StartX     float32
+	TargetX    float32
+}
+
+// NewPlant builds a plant of a known type with the given stats, see
+// Registry.
+func NewPlant(id int, typeStr string, x, y float32, stats PlantStats) *Plant {
+	p := &Plant{
+		ID:        id,
+		Type:      typeStr,
+		X:         x,
+		Y:         y,
+		Damage:    stats.Damage,
+		Health:    stats.Health,
+		MaxHealth: stats.Health,
+		AnimState: AnimIdle,
+		Skeleton:  NewSkeleton(x, y),
+	}
+
+	// ShootInterval is whichever timer drives the plant
+	switch typeStr {
+	case "peashooter", "snowpea", "repeater":
+		p.ShootInterval = stats.ShootInterval
+		p.Lanes = []int{0}
+		p.Skeleton.AddBone("", newHeadBone())
+	case "threepeater":
+		p.ShootInterval = stats.ShootInterval
+		p.Lanes = []int{-1, 0, 1}
+		p.Skeleton.AddBone("", newHeadBone())
+	case "sunflower":
+		p.ShootInterval = stats.ProductionInterval
+		p.SunValue = stats.SunValue
+		if p.SunValue == 0 {
+			p.SunValue = sunValue
+		}
+	case "cherrybomb":
+		p.ShootInterval = stats.FuseTime
+	case "potatomine":
+		p.ShootInterval = stats.ArmingTime
+	}
+
+	return p
+}
+
+func (p *Plant) Update(w *World, dt float32) {
+	p.Timer += dt
+
+	if p.Skeleton != nil {
+		p.Skeleton.X = p.X
+		p.Skeleton.Y = p.Y
+		p.Skeleton.Update(dt)
+	}
+
+	if p.Type == "peashooter" || p.Type == "snowpea" || p.Type == "threepeater" {
+		if p.Timer > p.ShootInterval {
+			p.Timer = 0
+			p.shoot(w)
+		}
+	} else if p.Type == "repeater" {
+		if p.Timer > p.ShootInterval {
+			p.Timer = 0
+			p.shoot(w)
+			p.ShotsFired = 1
+			p.BurstTimer = 200
+		}
+		if p.ShotsFired == 1 {
+			p.BurstTimer -= dt
+			if p.BurstTimer <= 0 {
+				p.shoot(w)
+				p.ShotsFired = 0
+			}
+		}
+	} else if p.Type == "sunflower" {
+		if p.Timer > p.ShootInterval {
+			p.Timer = 0
+			evt := plantEvent(EventSpawnSun, p)
+			evt.Amount = float32(p.SunValue)
+			w.emit(evt)
+			y := p.Y + sunflowerDropY
+			w.dropSun(p.X+sunflowerDropX, y, y+sunflowerDropFall, p.SunValue, p.Type)
+		}
+	} else if p.Type == "squash" {
+		w.updateSquash(p, dt)
+	} else if p.Type == "cherrybomb" {
+		if p.Timer > p.ShootInterval {
+			w.explode(p, 1)
+		}
+	} else if p.Type == "potatomine" {
+		if !p.IsArmed && p.Timer > p.ShootInterval {
+			p.IsArmed = true
+			p.Timer = 0 // Reset or stay high?
+			w.emit(plantEvent(EventArm, p))
+		} else if p.IsArmed && w.mineTriggered(p) {
+			w.explode(p, 0)
+		}
+	}
+}
+
+// shoot tells the renderer to play the firing animation and spawns one
+// projectile per lane in Go; the renderer only draws them. Lanes off the
+// edge of the lawn are skipped, so a threepeater on the top row fires two.
+func (p *Plant) shoot(w *World) {
+	var rows []int
+	for _, offset := range p.Lanes {
+		if row := p.Row + offset; row >= 0 && row < w.Grid.Rows {
+			rows = append(rows, row)
+		}
+	}
+
+	evt := plantEvent(EventShoot, p)
+	evt.Amount = float32(len(rows))
+	w.emit(evt)
+	for _, row := range rows {
+		w.fire(p, row)
+	}
+}
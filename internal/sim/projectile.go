@@ -0,0 +1,124 @@
+package sim
+
+import (
+	"maps"
+	"slices"
+)
+
+// Projectile geometry and speed, matching the old Projectile.js.
+const (
+	projectileSpeed = 0.4 // px per ms
+	projectileSize  = 20
+
+	// Zombie hitbox width, same as Entity.js
+	zombieWidth = 80
+)
+
+// Where a shooter's "head" bone sits relative to its top-left; shots start
+// from the bone.
+const (
+	muzzleOffsetX = 60
+	muzzleOffsetY = 30
+)
+
+type Projectile struct {
+	ID      int
+	OwnerID int    // plant that fired it
+	Kind    string // plant type that fired it, decides on-hit effects
+	Row     int    // only zombies in this lane can be hit
+	X, Y    float32
+	Speed   float32
+	Damage  float32
+}
+
+// fire spawns a projectile from p into lane row.
+func (w *World) fire(p *Plant, row int) *Projectile {
+	x, y := p.muzzle()
+	// Shots into other lanes keep the muzzle height relative to the lane
+	y += float32(row-p.Row) * float32(w.Grid.CellSize)
+
+	pr := &Projectile{
+		ID:      w.newEntityID(),
+		OwnerID: p.ID,
+		Kind:    p.Type,
+		Row:     row,
+		X:       x,
+		Y:       y,
+		Speed:   projectileSpeed,
+		Damage:  p.Damage,
+	}
+	w.Projectiles[pr.ID] = pr
+	w.emit(projectileEvent(EventProjectileSpawned, pr))
+	return pr
+}
+
+// newHeadBone is the bone a shooter fires from. It carries no image; the
+// renderer still draws the plant itself.
+func newHeadBone() *Bone {
+	return &Bone{Name: "head", LocalX: muzzleOffsetX, LocalY: muzzleOffsetY, ScaleX: 1, ScaleY: 1}
+}
+
+// muzzle returns where p's shots start: its "head" bone, or the same offset
+// from its cell position if it has none.
+func (p *Plant) muzzle() (float32, float32) {
+	if p.Skeleton != nil {
+		if head, ok := p.Skeleton.Bones["head"]; ok {
+			return head.WorldX, head.WorldY
+		}
+	}
+	return p.X + muzzleOffsetX, p.Y + muzzleOffsetY
+}
+
+// updateProjectiles moves every projectile and resolves hits. A projectile
+// hits the front-most zombie in its lane that it overlaps, then is removed.
+func (w *World) updateProjectiles(dt float32) {
+	rightEdge := float32(w.Grid.StartX + float64(w.Grid.Cols+1)*w.Grid.CellSize)
+
+	for _, id := range slices.Sorted(maps.Keys(w.Projectiles)) {
+		pr := w.Projectiles[id]
+		pr.X += pr.Speed * dt
+
+		if z := w.projectileTarget(pr); z != nil {
+			w.damageZombie(z, pr.Damage, DamageProjectile)
+			evt := projectileEvent(EventProjectileHit, pr)
+			evt.Target = z.ID
+			evt.Amount = pr.Damage
+			w.emit(evt)
+			if kind, duration, ok := onHitStatus(pr.Kind); ok {
+				w.ApplyStatus(z, kind, duration)
+			}
+			delete(w.Projectiles, id)
+			continue
+		}
+
+		if pr.X > rightEdge {
+			w.emit(projectileEvent(EventProjectileExpired, pr))
+			delete(w.Projectiles, id)
+		}
+	}
+}
+
+func (w *World) projectileTarget(pr *Projectile) *Zombie {
+	var target *Zombie
+	for _, id := range slices.Sorted(maps.Keys(w.Zombies)) {
+		z := w.Zombies[id]
+		if z.Row != pr.Row || z.Health <= 0 {
+			continue
+		}
+		if pr.X+projectileSize < z.X || pr.X > z.X+zombieWidth {
+			continue
+		}
+		if target == nil || z.X < target.X {
+			target = z
+		}
+	}
+	return target
+}
+
+func (w *World) DestroyProjectile(id int) bool {
+	if _, ok := w.Projectiles[id]; !ok {
+		return false
+	}
+	delete(w.Projectiles, id)
+	return true
+}
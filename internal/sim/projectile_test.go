@@ -0,0 +1,112 @@
+package sim
+
+import "testing"
+
+func TestProjectileHitsFrontZombieInLane(t *testing.T) {
+	w := NewWorld()
+	p := plantIn(t, w, "peashooter", 2, 0)
+	front := zombieIn(t, w, "basic", 2, 700)
+	back := zombieIn(t, w, "basic", 2, 760)
+	other := zombieIn(t, w, "basic", 1, 600)
+
+	hits := runUntil(t, w, EventProjectileHit, 600)
+	if len(hits) != 1 {
+		t.Fatalf("got %d hits, want 1", len(hits))
+	}
+	if hits[0].Target != front.ID {
+		t.Errorf("hit zombie %d, want the front one %d", hits[0].Target, front.ID)
+	}
+	if got, want := front.Health, front.MaxHealth-p.Damage; got != want {
+		t.Errorf("front zombie health = %v, want %v", got, want)
+	}
+	for _, z := range []*Zombie{back, other} {
+		if z.Health != z.MaxHealth {
+			t.Errorf("zombie %d took damage", z.ID)
+		}
+	}
+	if _, ok := w.Projectiles[hits[0].ID]; ok {
+		t.Error("projectile still in the world after hitting")
+	}
+}
+
+func TestProjectileLeavesFromHeadBone(t *testing.T) {
+	w := NewWorld()
+	p := plantIn(t, w, "peashooter", 2, 0)
+	head := p.Skeleton.Bones["head"]
+	if head == nil {
+		t.Fatal("peashooter has no head bone")
+	}
+	head.LocalX += 15
+	head.LocalY -= 10
+
+	spawned := runUntil(t, w, EventProjectileSpawned, 600)[0]
+	if spawned.X != head.WorldX || spawned.Y != head.WorldY {
+		t.Errorf("shot spawned at (%v, %v), want the head bone at (%v, %v)", spawned.X, spawned.Y, head.WorldX, head.WorldY)
+	}
+	if want := p.X + muzzleOffsetX + 15; head.WorldX != want {
+		t.Errorf("head bone at x %v, want %v", head.WorldX, want)
+	}
+}
+
+func TestProjectileExpiresOffLawn(t *testing.T) {
+	w := NewWorld()
+	plantIn(t, w, "peashooter", 0, 0)
+	zombieIn(t, w, "basic", 1, 600) // another lane, never hit
+
+	expired := runUntil(t, w, EventProjectileExpired, 2000)
+	if _, ok := w.Projectiles[expired[0].ID]; ok {
+		t.Error("expired projectile still in the world")
+	}
+}
+
+func TestThreepeaterFiresIntoThreeLanes(t *testing.T) {
+	w := NewWorld()
+	p := plantIn(t, w, "threepeater", 2, 0)
+
+	shot := runUntil(t, w, EventShoot, 600)[0]
+	if shot.Amount != 3 {
+		t.Errorf("shoot amount = %v, want 3", shot.Amount)
+	}
+	rows := map[int]float32{}
+	for _, pr := range w.Projectiles {
+		rows[pr.Row] = pr.Y
+	}
+	for _, row := range []int{1, 2, 3} {
+		y, ok := rows[row]
+		if !ok {
+			t.Errorf("no projectile in lane %d", row)
+			continue
+		}
+		// Each shot keeps the muzzle height within its own lane
+		_, py := p.muzzle()
+		if want := py + float32(row-p.Row)*float32(w.Grid.CellSize); y != want {
+			t.Errorf("lane %d projectile at y = %v, want %v", row, y, want)
+		}
+	}
+}
+
+func TestThreepeaterSkipsLanesOffTheGrid(t *testing.T) {
+	w := NewWorld()
+	plantIn(t, w, "threepeater", 0, 0)
+
+	shot := runUntil(t, w, EventShoot, 600)[0]
+	if shot.Amount != 2 {
+		t.Errorf("shoot amount = %v, want 2 from the top lane", shot.Amount)
+	}
+	for _, pr := range w.Projectiles {
+		if pr.Row < 0 || pr.Row > 1 {
+			t.Errorf("projectile fired into lane %d", pr.Row)
+		}
+	}
+}
+
+func TestThreepeaterHitsZombieInSideLane(t *testing.T) {
+	w := NewWorld()
+	plantIn(t, w, "threepeater", 2, 0)
+	z := zombieIn(t, w, "basic", 3, 700)
+
+	hit := runUntil(t, w, EventProjectileHit, 600)[0]
+	if hit.Target != z.ID || hit.Row != 3 {
+		t.Errorf("hit zombie %d in lane %d, want %d in lane 3", hit.Target, hit.Row, z.ID)
+	}
+}
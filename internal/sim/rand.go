@@ -0,0 +1,42 @@
+package sim
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// DefaultSeed is used until a bridge or client calls SetSeed.
+const DefaultSeed = 1
+
+// Every random choice the simulation makes goes through the world's RNG so a
+// level run can be reproduced exactly from its seed. Never use the global
+// math/rand functions in this package.
+
+// SetSeed resets the world's random source. Call it before the level starts;
+// reseeding mid-run makes the rest of the run depend on when it happened.
+func (w *World) SetSeed(seed uint64) {
+	w.Seed = seed
+	w.rng = rand.NewPCG(seed, seed^0x9e3779b97f4a7c15)
+	w.Rand = rand.New(w.rng)
+}
+
+// RandomLane picks a spawn row on the current grid.
+func (w *World) RandomLane() int {
+	return w.Rand.IntN(w.Grid.Rows)
+}
+
+// RandomSkySun picks where a falling sun lands: x anywhere over the lawn
+// columns and a resting y somewhere over the lawn rows.
+func (w *World) RandomSkySun() (x, targetY float32) {
+	g := w.Grid
+	x = float32(g.StartX + w.Rand.Float64()*float64(g.Cols-1)*g.CellSize)
+	targetY = float32(g.StartY + w.Rand.Float64()*float64(g.Rows-1)*g.CellSize)
+	return x, targetY
+}
+
+// varyZombie gives each zombie its own gait so a horde doesn't walk in
+// lock-step. Only animation is touched; Speed stays as defined for balance.
+func (w *World) varyZombie(z *Zombie) {
+	z.WalkSpeed *= 0.9 + 0.2*w.Rand.Float32()
+	z.AnimTime = w.Rand.Float32() * 2 * math.Pi
+}
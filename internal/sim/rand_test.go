@@ -0,0 +1,41 @@
+package sim
+
+import (
+	"slices"
+	"testing"
+)
+
+// draws returns the next n lanes and sky-sun spots from w's RNG.
+func draws(w *World, n int) []float32 {
+	var out []float32
+	for range n {
+		x, y := w.RandomSkySun()
+		out = append(out, float32(w.RandomLane()), x, y)
+	}
+	return out
+}
+
+func TestSeedDecidesRandomSequence(t *testing.T) {
+	a, b, c := NewWorld(), NewWorld(), NewWorld()
+	a.SetSeed(42)
+	b.SetSeed(42)
+	c.SetSeed(43)
+
+	first := draws(a, 20)
+	if !slices.Equal(first, draws(b, 20)) {
+		t.Error("same seed gave different sequences")
+	}
+	if slices.Equal(first, draws(c, 20)) {
+		t.Error("different seeds gave the same sequence")
+	}
+
+	a.SetSeed(42)
+	if !slices.Equal(first, draws(a, 20)) {
+		t.Error("reseeding did not restart the sequence")
+	}
+	for i := 0; i < len(first); i += 3 {
+		if lane := int(first[i]); lane < 0 || lane >= a.Grid.Rows {
+			t.Fatalf("lane %d outside the %d-row grid", lane, a.Grid.Rows)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package sim
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"pvz"
+)
+
+// Registry holds the stats of every plant and zombie type the simulation
+// knows, loaded from public/data/plants.json and zombies.json. Behaviour
+// (how a type shoots, explodes or wears armor) stays in Go; every number
+// that tunes it comes from here, so editing the data files changes play.
+type Registry struct {
+	Plants  map[string]PlantStats
+	Zombies map[string]ZombieStats
+}
+
+// PlantStats is one entry of public/data/plants.json. Timing fields are in
+// ms; which one applies depends on the plant.
+type PlantStats struct {
+	SunCost            float32 `json:"sunCost"`
+	Health             float32 `json:"health"`
+	Damage             float32 `json:"damage"`
+	Cooldown           float32 `json:"cooldown"`
+	ShootInterval      float32 `json:"shootInterval,omitempty"`
+	ProductionInterval float32 `json:"productionInterval,omitempty"`
+	FuseTime           float32 `json:"fuseTime,omitempty"`
+	ArmingTime         float32 `json:"armingTime,omitempty"`
+	SunValue           int     `json:"sunValue,omitempty"` // per drop, producers only
+
+	// Wear tiers, highest threshold first; see damage.go
+	DamageStates []DamageState `json:"damageStates,omitempty"`
+}
+
+// ZombieStats is one entry of public/data/zombies.json.
+type ZombieStats struct {
+	Health float32      `json:"health"` // body only; armor has its own pool
+	Speed  float32      `json:"speed"`  // px per ms
+	Damage float32      `json:"damage"` // per 60 Hz tick, see biteDamage
+	Armor  *ArmorConfig `json:"armor,omitempty"`
+}
+
+// ArmorConfig puts armor of a kind from armorKinds on the zombie.
+type ArmorConfig struct {
+	Kind   string  `json:"kind"`
+	Health float32 `json:"health"`
+}
+
+// plantKinds lists the plant types with behaviour in the simulation and the
+// plants.json fields each one needs on top of sunCost, health and cooldown.
+// plantern and blover only act on the JS side (fog), and lily_pad and
+// tangle_kelp only matter to placement, but all are still placed through
+// the simulation.
+var plantKinds = map[string][]string{
+	"peashooter":  {"damage", "shootInterval"},
+	"snowpea":     {"damage", "shootInterval"},
+	"repeater":    {"damage", "shootInterval"},
+	"threepeater": {"damage", "shootInterval"},
+	"sunflower":   {"productionInterval"},
+	"cherrybomb":  {"damage", "fuseTime"},
+	"potatomine":  {"damage", "armingTime"},
+	"squash":      {"damage"},
+	"wallnut":     nil,
+	"tallnut":     nil,
+	"pumpkin":     nil,
+	"plantern":    nil,
+	"blover":      nil,
+	"lily_pad":    nil,
+	"tangle_kelp": nil,
+}
+
+var plantRequired = []string{"sunCost", "health", "cooldown"}
+
+// zombieKinds lists the zombie types the simulation knows.
+var zombieKinds = []string{"basic", "conehead", "buckethead", "football", "boss"}
+
+var zombieRequired = []string{"health", "speed", "damage"}
+
+// ParseRegistry reads plants.json and zombies.json. Both files must have an
+// entry for every known type with all of its required fields, and nothing
+// else; the error is a FieldErrors listing every problem found.
+func ParseRegistry(plants, zombies []byte) (*Registry, error) {
+	p, perrs := ValidatePlants(plants)
+	z, zerrs := ValidateZombies(zombies)
+	if errs := append(perrs.In("plants.json"), zerrs.In("zombies.json")...); len(errs) > 0 {
+		return nil, errs
+	}
+	return &Registry{Plants: p, Zombies: z}, nil
+}
+
+// ValidatePlants checks the contents of plants.json on its own.
+func ValidatePlants(data []byte) (map[string]PlantStats, FieldErrors) {
+	return validateTable(data, "plant", slices.Sorted(maps.Keys(plantKinds)),
+		func(typ string) []string { return slices.Concat(plantRequired, plantKinds[typ]) },
+		PlantStats.check)
+}
+
+// ValidateZombies checks the contents of zombies.json on its own.
+func ValidateZombies(data []byte) (map[string]ZombieStats, FieldErrors) {
+	return validateTable(data, "zombie", zombieKinds,
+		func(string) []string { return zombieRequired },
+		ZombieStats.check)
+}
+
+// validateTable reads a {type: stats} file. Every type in known must be
+// there with its required fields; anything else is an error.
+func validateTable[T any](data []byte, what string, known []string, required func(string) []string, check func(T) FieldErrors) (map[string]T, FieldErrors) {
+	var entries map[string]json.RawMessage
+	if errs := decodeStrict(data, &entries); errs != nil {
+		return nil, errs
+	}
+
+	table := make(map[string]T)
+	var errs FieldErrors
+	for _, typ := range slices.Sorted(maps.Keys(entries)) {
+		if !slices.Contains(known, typ) {
+			errs.add(typ, "unknown %s type", what)
+			continue
+		}
+
+		var fields map[string]json.RawMessage
+		if e := decodeStrict(entries[typ], &fields); e != nil {
+			errs = append(errs, e.under(typ)...)
+			continue
+		}
+		var entryErrs FieldErrors
+		for _, f := range required(typ) {
+			if _, ok := fields[f]; !ok {
+				entryErrs.add(f, "missing")
+			}
+		}
+		var stats T
+		if e := decodeStrict(entries[typ], &stats); e != nil {
+			entryErrs = append(entryErrs, e...)
+		} else {
+			entryErrs = append(entryErrs, check(stats)...)
+		}
+		if len(entryErrs) > 0 {
+			errs = append(errs, entryErrs.under(typ)...)
+			continue
+		}
+		table[typ] = stats
+	}
+	for _, typ := range known {
+		if _, ok := entries[typ]; !ok {
+			errs.add(typ, "missing")
+		}
+	}
+	return table, errs
+}
+
+func (s PlantStats) check() FieldErrors {
+	var errs FieldErrors
+	if s.SunCost < 0 {
+		errs.add("sunCost", "must not be negative")
+	}
+	if s.Health <= 0 {
+		errs.add("health", "must be positive")
+	}
+	if s.Damage < 0 {
+		errs.add("damage", "must not be negative")
+	}
+	timers := []struct {
+		name string
+		ms   float32
+	}{
+		{"cooldown", s.Cooldown},
+		{"shootInterval", s.ShootInterval},
+		{"productionInterval", s.ProductionInterval},
+		{"fuseTime", s.FuseTime},
+		{"armingTime", s.ArmingTime},
+	}
+	for _, t := range timers {
+		if t.ms < 0 {
+			errs.add(t.name, "must not be negative")
+		}
+	}
+	if s.SunValue < 0 {
+		errs.add("sunValue", "must not be negative")
+	}
+	for i, ds := range s.DamageStates {
+		path := index("damageStates", i)
+		if ds.Below <= 0 || ds.Below >= 1 {
+			errs.add(path+".below", "must be between 0 and 1")
+		} else if i > 0 && ds.Below >= s.DamageStates[i-1].Below {
+			errs.add(path+".below", "must be lower than the one before")
+		}
+	}
+	return errs
+}
+
+func (s ZombieStats) check() FieldErrors {
+	var errs FieldErrors
+	if s.Health <= 0 {
+		errs.add("health", "must be positive")
+	}
+	if s.Speed < 0 {
+		errs.add("speed", "must not be negative")
+	}
+	if s.Damage < 0 {
+		errs.add("damage", "must not be negative")
+	}
+	if a := s.Armor; a != nil {
+		if _, ok := armorKinds[a.Kind]; !ok {
+			errs.add("armor.kind", "unknown armor %q", a.Kind)
+		}
+		if a.Health <= 0 {
+			errs.add("armor.health", "must be positive")
+		}
+	}
+	return errs
+}
+
+// LoadRegistry replaces the world's stats with the contents of plants.json
+// and zombies.json. Entities created afterwards use the new numbers; ones
+// already on the lawn keep theirs.
+func (w *World) LoadRegistry(plants, zombies []byte) error {
+	r, err := ParseRegistry(plants, zombies)
+	if err != nil {
+		return err
+	}
+	w.Registry = r
+	return nil
+}
+
+// DefaultRegistry is the stock contents of public/data, embedded at build
+// time and used until LoadRegistry is called. It is parsed once and shared;
+// registries are replaced, never modified.
+var DefaultRegistry = sync.OnceValue(func() *Registry {
+	r, err := ParseRegistry(pvz.PlantsJSON, pvz.ZombiesJSON)
+	if err != nil {
+		panic(fmt.Sprintf("stock game data: %v", err))
+	}
+	return r
+})
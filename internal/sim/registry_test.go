@@ -0,0 +1,134 @@
+package sim
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"pvz"
+)
+
+func TestDefaultRegistryCoversKinds(t *testing.T) {
+	r := DefaultRegistry()
+	for _, kind := range slices.Sorted(maps.Keys(plantKinds)) {
+		if _, ok := r.Plants[kind]; !ok {
+			t.Errorf("plant %q missing from the stock registry", kind)
+		}
+	}
+	for _, kind := range zombieKinds {
+		if _, ok := r.Zombies[kind]; !ok {
+			t.Errorf("zombie %q missing from the stock registry", kind)
+		}
+	}
+}
+
+func TestLilyPadCarriesPlant(t *testing.T) {
+	w := NewWorld()
+	w.SetSun(1000)
+	for _, kind := range []string{"lily_pad", "peashooter"} {
+		if err := w.Submit(Command{Type: CmdPlacePlant, Row: 2, Col: 3, Kind: kind}); err != nil {
+			t.Fatalf("place %s: %v", kind, err)
+		}
+		w.Advance(TickDT)
+	}
+	if len(w.Plants) != 2 {
+		t.Fatalf("got %d plants, want the pad and the peashooter", len(w.Plants))
+	}
+	if p := w.PlantAt(2, 3); p == nil || p.Type != "peashooter" {
+		t.Errorf("PlantAt = %+v, want the peashooter on top", p)
+	}
+}
+
+// editStock decodes one of the stock data files, lets edit change it and
+// encodes it again.
+func editStock(t *testing.T, data []byte, edit func(map[string]map[string]any)) []byte {
+	t.Helper()
+	var table map[string]map[string]any
+	if err := json.Unmarshal(data, &table); err != nil {
+		t.Fatal(err)
+	}
+	edit(table)
+	out, err := json.Marshal(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestParseRegistryRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		plants  func(map[string]map[string]any)
+		zombies func(map[string]map[string]any)
+		want    FieldError
+	}{
+		{
+			name:    "unknown zombie",
+			zombies: func(z map[string]map[string]any) { z["ghost"] = z["basic"] },
+			want:    FieldError{File: "zombies.json", Path: "ghost", Message: "unknown zombie type"},
+		},
+		{
+			name:   "missing plant",
+			plants: func(p map[string]map[string]any) { delete(p, "squash") },
+			want:   FieldError{File: "plants.json", Path: "squash", Message: "missing"},
+		},
+		{
+			name:   "missing required field",
+			plants: func(p map[string]map[string]any) { delete(p["peashooter"], "shootInterval") },
+			want:   FieldError{File: "plants.json", Path: "peashooter.shootInterval", Message: "missing"},
+		},
+		{
+			name:   "unknown field",
+			plants: func(p map[string]map[string]any) { p["sunflower"]["sunPower"] = 50 },
+			want:   FieldError{File: "plants.json", Path: "sunflower.sunPower", Message: "unknown field"},
+		},
+		{
+			name:    "bad value",
+			zombies: func(z map[string]map[string]any) { z["football"]["health"] = 0 },
+			want:    FieldError{File: "zombies.json", Path: "football.health", Message: "must be positive"},
+		},
+		{
+			name: "unknown armor",
+			zombies: func(z map[string]map[string]any) {
+				z["conehead"]["armor"] = map[string]any{"kind": "hat", "health": 100}
+			},
+			want: FieldError{File: "zombies.json", Path: "conehead.armor.kind", Message: `unknown armor "hat"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plants, zombies := pvz.PlantsJSON, pvz.ZombiesJSON
+			if tt.plants != nil {
+				plants = editStock(t, plants, tt.plants)
+			}
+			if tt.zombies != nil {
+				zombies = editStock(t, zombies, tt.zombies)
+			}
+			r, err := ParseRegistry(plants, zombies)
+			if r != nil {
+				t.Fatal("got a registry, want an error")
+			}
+			var errs FieldErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("error %v is not a FieldErrors", err)
+			}
+			if !slices.Contains(errs, tt.want) {
+				t.Errorf("errors:\n%v\nwant %v", errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadRegistryKeepsOldOnError(t *testing.T) {
+	w := NewWorld()
+	old := w.Registry
+	bad := editStock(t, pvz.ZombiesJSON, func(z map[string]map[string]any) { delete(z, "boss") })
+	if err := w.LoadRegistry(pvz.PlantsJSON, bad); err == nil {
+		t.Fatal("LoadRegistry accepted a zombies.json without the boss")
+	}
+	if w.Registry != old {
+		t.Error("a rejected registry replaced the old one")
+	}
+}
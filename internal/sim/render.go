@@ -0,0 +1,71 @@
+package sim
+
+import (
+	"encoding/binary"
+	"slices"
+)
+
+// Render buffer layout. Every field is a little-endian 4-byte word so the
+// renderer can view the same ArrayBuffer as both Uint32Array and
+// Float32Array and walk it by word index.
+//
+//	header:   version u32, skeletonCount u32, floatsPerBone u32
+//	skeleton: skeletonID u32, entityID u32 (0 = free-standing), boneCount u32
+//	bone:     x, y, rot, scaleX, scaleY, imageID, pivotX, pivotY (f32 each)
+//
+// Skeletons follow the header back to back in skeleton ID order; bones are
+// in the same depth-first order as Skeleton.GetRenderData. Bone transforms
+// are blended between the previous tick and the latest one by the same
+// alpha Advance reports, so skeletons move in step with interpolated
+// entity positions.
+const (
+	RenderBufferVersion = 1
+	RenderFloatsPerBone = 8
+)
+
+// AppendRenderBuffer encodes every visible skeleton onto buf and returns the
+// extended slice. Bone records go straight onto buf; pass buf[:0] of a
+// previous result and a frame allocates nothing once buf is big enough.
+func (w *World) AppendRenderBuffer(buf []byte) []byte {
+	start := len(buf)
+	buf = appendWord(buf, RenderBufferVersion)
+	buf = appendWord(buf, 0) // patched below
+	buf = appendWord(buf, RenderFloatsPerBone)
+
+	alpha := w.Clock.Accumulator / TickDT
+	count := 0
+	w.renderIDs = w.renderIDs[:0]
+	for id := range w.Skeletons {
+		w.renderIDs = append(w.renderIDs, id)
+	}
+	slices.Sort(w.renderIDs)
+	for _, id := range w.renderIDs {
+		s := w.Skeletons[id]
+		if s.Root == nil || !w.skeletonVisible(s) {
+			continue
+		}
+		count++
+
+		buf = appendWord(buf, uint32(id))
+		buf = appendWord(buf, uint32(s.ownerID))
+		countAt := len(buf)
+		buf = appendWord(buf, 0) // patched below
+		buf = s.appendBlendedBones(s.Root, alpha, buf)
+		bones := (len(buf) - countAt - 4) / (4 * RenderFloatsPerBone)
+		binary.LittleEndian.PutUint32(buf[countAt:], uint32(bones))
+	}
+
+	binary.LittleEndian.PutUint32(buf[start+4:], uint32(count))
+	return buf
+}
+
+func (w *World) skeletonVisible(s *Skeleton) bool {
+	if d, ok := w.Daves[s.ownerID]; ok {
+		return d.Visible
+	}
+	return true
+}
+
+func appendWord(buf []byte, v uint32) []byte {
+	return binary.LittleEndian.AppendUint32(buf, v)
+}
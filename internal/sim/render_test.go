@@ -0,0 +1,78 @@
+package sim
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// rootX finds skeleton id in a render buffer and returns its root bone's x.
+func rootX(t *testing.T, buf []byte, id int) float32 {
+	t.Helper()
+	word := func(i int) uint32 { return binary.LittleEndian.Uint32(buf[i*4:]) }
+	w := 3
+	for range word(1) {
+		if int(word(w)) == id {
+			return math.Float32frombits(word(w + 3))
+		}
+		w += 3 + int(word(w+2))*RenderFloatsPerBone
+	}
+	t.Fatalf("skeleton %d not in the render buffer", id)
+	return 0
+}
+
+func TestRenderBufferInterpolatesBones(t *testing.T) {
+	w := NewWorld()
+	z := w.CreateZombie("basic", 800, 100)
+	w.Advance(TickDT)
+	before := z.Skeleton.Root.WorldX
+	w.Advance(TickDT)
+	after := z.Skeleton.Root.WorldX
+	if before == after {
+		t.Fatal("zombie did not move")
+	}
+
+	if got := rootX(t, w.AppendRenderBuffer(nil), z.SkeletonID); got != before {
+		t.Errorf("right after a tick: x = %v, want the previous %v", got, before)
+	}
+	w.Advance(TickDT / 2)
+	if got, want := rootX(t, w.AppendRenderBuffer(nil), z.SkeletonID), (before+after)/2; math.Abs(float64(got-want)) > 1e-3 {
+		t.Errorf("half way to the next tick: x = %v, want %v", got, want)
+	}
+}
+
+func TestDaveDrawnOnceHeAppears(t *testing.T) {
+	w := NewWorld()
+	d := w.CreateDave(0, 100)
+	d.Skeleton.AddBone("", &Bone{Name: "body", ScaleX: 1, ScaleY: 1})
+	skeletons := func() uint32 { return binary.LittleEndian.Uint32(w.AppendRenderBuffer(nil)[4:]) }
+
+	w.Advance(TickDT)
+	if n := skeletons(); n != 0 {
+		t.Fatalf("%d skeletons drawn before Dave appeared", n)
+	}
+	d.Appear()
+	w.Advance(TickDT)
+	if n := skeletons(); n != 1 {
+		t.Errorf("%d skeletons drawn after Dave appeared, want 1", n)
+	}
+	if d.X <= daveStartX {
+		t.Errorf("Dave at x = %v, want him sliding in from %v", d.X, daveStartX)
+	}
+}
+
+func TestRenderBufferReusesCallerBuffer(t *testing.T) {
+	w := NewWorld()
+	for row := range 5 {
+		zombieIn(t, w, "basic", row, 700)
+	}
+	w.Advance(TickDT * 1.5)
+	buf := w.AppendRenderBuffer(nil)
+
+	allocs := testing.AllocsPerRun(20, func() {
+		buf = w.AppendRenderBuffer(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("%v allocations per frame, want none", allocs)
+	}
+}
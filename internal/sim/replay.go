@@ -0,0 +1,191 @@
+package sim
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ReplayVersion is bumped when the replay file layout or command semantics
+// change incompatibly.
+const ReplayVersion = 1
+
+// Replay is a recorded play session: the starting conditions plus every
+// command with the tick (relative to the start) it applied on.
+type Replay struct {
+	Version  int       `json:"version"`
+	Seed     uint64    `json:"seed"`
+	RNG      []byte    `json:"rng"` // PCG state at the start; draws since seeding are already in it
+	Grid     Grid      `json:"grid"`
+	Commands []Command `json:"commands"`
+
+	// Starting economy; placements depend on it
+	Sun            int     `json:"sun"`
+	SkySunInterval float32 `json:"skySunInterval"`
+
+	// Level being played, if StartLevel ran before recording started, or
+	// the endless settings if StartEndless did
+	Waves   []Wave         `json:"waves,omitempty"`
+	Endless *EndlessConfig `json:"endless,omitempty"`
+
+	// First entity and skeleton IDs handed out after recording started.
+	// Commands name suns by ID, so playback has to count from the same
+	// place.
+	NextEntityID int `json:"nextEntityID"`
+	NextSkelID   int `json:"nextSkelID"`
+
+	startTick uint64
+}
+
+// StartRecording begins capturing commands. Call it right after Reset,
+// seeding, setting up the grid and economy and starting the level, before
+// the first tick of the level. A replay only holds those starting
+// conditions, so it refuses while anything from an earlier run is left.
+func (w *World) StartRecording() error {
+	if err := w.checkFreshRun(); err != nil {
+		return fmt.Errorf("replay: can't record, %w", err)
+	}
+	rngState, err := w.rng.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("replay: can't record, rng: %w", err)
+	}
+	w.recording = &Replay{
+		Version:  ReplayVersion,
+		Seed:     w.Seed,
+		RNG:      rngState,
+		Grid:     *w.Grid,
+		Commands: []Command{},
+
+		Sun:            w.Economy.Sun,
+		SkySunInterval: w.Economy.SkySunInterval,
+		NextEntityID:   w.nextEntityID,
+		NextSkelID:     w.nextSkelID,
+		startTick:      w.Clock.Tick,
+	}
+	if m := w.Waves; m != nil && m.Endless != nil {
+		w.recording.Endless = m.Endless
+	} else if m != nil {
+		w.recording.Waves = m.Waves
+	}
+	return nil
+}
+
+// checkFreshRun reports state that Play couldn't rebuild from a Replay.
+func (w *World) checkFreshRun() error {
+	switch {
+	case w.playback != nil:
+		return fmt.Errorf("a replay is playing")
+	case len(w.Zombies)+len(w.Plants)+len(w.Projectiles)+len(w.Suns)+len(w.Debris) > 0:
+		return fmt.Errorf("the lawn still has entities from an earlier run")
+	case len(w.pending) > 0:
+		return fmt.Errorf("commands are already queued")
+	case w.Economy.SkySunTimer != 0:
+		return fmt.Errorf("the sky sun timer is running")
+	}
+	for kind, ms := range w.Economy.Recharge {
+		if ms > 0 {
+			return fmt.Errorf("%s is still recharging", kind)
+		}
+	}
+	if m := w.Waves; m != nil && (m.Index > 0 || m.Timer > 0 || m.State == WaveSpawning || m.State == WaveWaitingToClear) {
+		return fmt.Errorf("the level has already started")
+	}
+	return nil
+}
+
+// StopRecording ends capture and returns the replay, or nil if none was
+// running.
+func (w *World) StopRecording() *Replay {
+	rec := w.recording
+	w.recording = nil
+	return rec
+}
+
+// Play replaces the world with the starting conditions of rep, then feeds
+// its commands in on their recorded ticks. The world is cleared as by
+// Reset, and IDs count on from where the recording's did. On error the
+// world is left untouched.
+func (w *World) Play(rep *Replay) error {
+	if rep.Version != ReplayVersion {
+		return fmt.Errorf("replay: version %d, expected %d", rep.Version, ReplayVersion)
+	}
+	for i, cmd := range rep.Commands {
+		if i > 0 && cmd.Tick < rep.Commands[i-1].Tick {
+			return fmt.Errorf("replay: command %d is out of tick order", i)
+		}
+	}
+
+	fresh := w.cleared()
+	fresh.nextEntityID = rep.NextEntityID
+	fresh.nextSkelID = rep.NextSkelID
+	for id := range fresh.Daves {
+		if id >= rep.NextEntityID {
+			return fmt.Errorf("replay: Dave %d would clash with the recorded IDs", id)
+		}
+	}
+	for id := range fresh.Skeletons {
+		if id >= rep.NextSkelID {
+			return fmt.Errorf("replay: skeleton %d would clash with the recorded IDs", id)
+		}
+	}
+
+	fresh.SetSeed(rep.Seed)
+	if err := fresh.rng.UnmarshalBinary(rep.RNG); err != nil {
+		return fmt.Errorf("replay: rng: %w", err)
+	}
+	*fresh.Grid = rep.Grid
+	fresh.SetSun(rep.Sun)
+	fresh.SetSkySunInterval(rep.SkySunInterval)
+	if rep.Endless != nil {
+		if err := fresh.StartEndless(rep.Endless); err != nil {
+			return fmt.Errorf("replay: %w", err)
+		}
+	} else if rep.Waves != nil {
+		if err := fresh.StartLevel(rep.Waves); err != nil {
+			return fmt.Errorf("replay: %w", err)
+		}
+	}
+	for i, cmd := range rep.Commands {
+		if err := fresh.checkCommand(cmd); err != nil {
+			return fmt.Errorf("replay: command %d: %w", i, err)
+		}
+	}
+	fresh.playback = &playback{commands: rep.Commands}
+	*w = *fresh
+	return nil
+}
+
+// Playing reports whether a replay still has commands left to apply.
+func (w *World) Playing() bool {
+	return w.playback != nil
+}
+
+// playback runs on a clock Play reset to zero, so recorded ticks apply as-is
+type playback struct {
+	commands []Command
+	next     int
+}
+
+// due returns the commands recorded for tick and advances past them.
+func (p *playback) due(tick uint64) []Command {
+	start := p.next
+	for p.next < len(p.commands) && p.commands[p.next].Tick <= tick {
+		p.next++
+	}
+	return p.commands[start:p.next]
+}
+
+func (p *playback) done() bool {
+	return p.next >= len(p.commands)
+}
+
+func ParseReplay(data []byte) (*Replay, error) {
+	var rep Replay
+	if err := json.Unmarshal(data, &rep); err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+	return &rep, nil
+}
+
+func (r *Replay) Marshal() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
@@ -0,0 +1,174 @@
+package sim
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+const replayTicks = 3000
+
+// playRecorded runs a session that collects every sun by ID once it has
+// landed and plants on a few fixed ticks.
+func playRecorded(w *World) {
+	plants := map[int]Command{
+		30:  {Type: CmdPlacePlant, Row: 0, Col: 0, Kind: "sunflower"},
+		400: {Type: CmdPlacePlant, Row: 2, Col: 1, Kind: "peashooter"},
+		900: {Type: CmdPlacePlant, Row: 4, Col: 2, Kind: "potatomine"},
+	}
+	for tick := range replayTicks {
+		if cmd, ok := plants[tick]; ok {
+			w.Submit(cmd)
+		}
+		for _, id := range slices.Sorted(maps.Keys(w.Suns)) {
+			if s := w.Suns[id]; s.Y >= s.TargetY {
+				w.Submit(Command{Type: CmdCollectSun, ID: id})
+			}
+		}
+		w.Advance(TickDT)
+	}
+}
+
+func TestReplayMatchesRecording(t *testing.T) {
+	rec := NewWorld()
+	rec.CreateDave(0, 0) // on the lawn before recording starts, as in the browser
+	rec.SetSeed(7)
+	rec.SetSkySunInterval(2000)
+	if err := rec.StartLevel(ProceduralWaves(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.StartRecording(); err != nil {
+		t.Fatal(err)
+	}
+	playRecorded(rec)
+	if rec.Economy.Sun <= StartingSun {
+		t.Fatalf("recording collected no sun (balance %d)", rec.Economy.Sun)
+	}
+
+	data, err := rec.StopRecording().Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rep, err := ParseReplay(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Same page after a previous run: Dave again, plus leftovers that would
+	// shift entity IDs if Play kept them
+	play := NewWorld()
+	play.CreateDave(0, 0)
+	play.CreateZombie("basic", 500, 100)
+	play.CreatePlant("wallnut", 300, 300)
+	if err := play.Play(rep); err != nil {
+		t.Fatal(err)
+	}
+	for range replayTicks {
+		play.Advance(TickDT)
+	}
+
+	if got, want := play.Economy.Sun, rec.Economy.Sun; got != want {
+		t.Errorf("sun = %d, want %d", got, want)
+	}
+	if got, want := slices.Sorted(maps.Keys(play.Suns)), slices.Sorted(maps.Keys(rec.Suns)); !slices.Equal(got, want) {
+		t.Errorf("suns on the lawn = %v, want %v", got, want)
+	}
+	if got, want := len(play.Plants), len(rec.Plants); got != want {
+		t.Errorf("%d plants, want %d", got, want)
+	}
+	for id, z := range rec.Zombies {
+		pz, ok := play.Zombies[id]
+		if !ok {
+			t.Errorf("zombie %d missing from the replay", id)
+			continue
+		}
+		if pz.X != z.X || pz.Health != z.Health {
+			t.Errorf("zombie %d at x=%v hp=%v, want x=%v hp=%v", id, pz.X, pz.Health, z.X, z.Health)
+		}
+	}
+	if got, want := len(play.Zombies), len(rec.Zombies); got != want {
+		t.Errorf("%d zombies, want %d", got, want)
+	}
+}
+
+func TestRestoreRefusedWhileRecording(t *testing.T) {
+	w := NewWorld()
+	data, err := w.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.StartRecording(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Restore(data); err == nil {
+		t.Fatal("Restore went ahead while recording")
+	}
+	if w.StopRecording() == nil {
+		t.Fatal("recording was dropped")
+	}
+}
+
+func TestRecordingNeedsAFreshRun(t *testing.T) {
+	w := playSome(t)
+	if err := w.StartRecording(); err == nil {
+		t.Fatal("recorded on top of a run in progress")
+	}
+
+	dave := w.CreateDave(0, 0)
+	w.Reset()
+	if c := w.Counts(); c.Zombies+c.Plants+c.Projectiles+c.Suns+c.Debris != 0 {
+		t.Fatalf("Reset left %+v", c)
+	}
+	if _, ok := w.Daves[dave.ID]; !ok {
+		t.Fatal("Reset dropped Crazy Dave")
+	}
+	if err := w.StartLevel(ProceduralWaves(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.StartRecording(); err != nil {
+		t.Fatalf("after Reset: %v", err)
+	}
+}
+
+func TestReplayKeepsDrawsBeforeRecording(t *testing.T) {
+	rec := NewWorld()
+	rec.SetSeed(7)
+	rec.SetSkySunInterval(2000)
+	// The bridge exposes the RNG, so JS can draw between seeding and recording
+	for range 5 {
+		rec.RandomLane()
+		rec.RandomSkySun()
+	}
+	if err := rec.StartLevel(ProceduralWaves(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.StartRecording(); err != nil {
+		t.Fatal(err)
+	}
+	playRecorded(rec)
+
+	play := NewWorld()
+	if err := play.Play(rec.StopRecording()); err != nil {
+		t.Fatal(err)
+	}
+	for range replayTicks {
+		play.Advance(TickDT)
+	}
+
+	if len(rec.Zombies) == 0 {
+		t.Fatal("recording spawned no zombies")
+	}
+	for id, z := range rec.Zombies {
+		if pz, ok := play.Zombies[id]; !ok || pz.Row != z.Row || pz.X != z.X {
+			t.Errorf("zombie %d differs from the recording", id)
+		}
+	}
+	for id, s := range rec.Suns {
+		if ps, ok := play.Suns[id]; !ok || ps.X != s.X || ps.TargetY != s.TargetY {
+			t.Errorf("sun %d differs from the recording", id)
+		}
+	}
+	if got, want := play.Economy.Sun, rec.Economy.Sun; got != want {
+		t.Errorf("sun = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,228 @@
+package sim
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SnapshotVersion is bumped whenever the snapshot layout changes in a way
+// older blobs can't be loaded into.
+const SnapshotVersion = 1
+
+// snapshot is the serialized form of a World. Entities are stored as-is;
+// their *Skeleton pointers are skipped and re-linked from SkeletonID on load.
+// Pending events are not saved, they belong to the frame that produced them.
+type snapshot struct {
+	Version int `json:"version"`
+
+	NextEntityID int `json:"nextEntityID"`
+	NextSkelID   int `json:"nextSkelID"`
+	NextAnimID   int `json:"nextAnimID"`
+
+	Zombies     map[int]*Zombie           `json:"zombies"`
+	Plants      map[int]*Plant            `json:"plants"`
+	Daves       map[int]*Dave             `json:"daves"`
+	Projectiles map[int]*Projectile       `json:"projectiles"`
+	Debris      map[int]*Debris           `json:"debris"`
+	Suns        map[int]*Sun              `json:"suns"`
+	Economy     Economy                   `json:"economy"`
+	Waves       *WaveManager              `json:"waves,omitempty"`
+	Skeletons   map[int]*skeletonSnapshot `json:"skeletons"`
+	Animations  map[int]*Animation        `json:"animations"`
+
+	Grid  Grid   `json:"grid"`
+	Clock Clock  `json:"clock"`
+	Seed  uint64 `json:"seed"`
+	RNG   []byte `json:"rng"` // PCG state, so restoring continues the same sequence
+
+	SelectedSeed string    `json:"selectedSeed"`
+	Pending      []Command `json:"pending"` // submitted but not yet applied
+}
+
+type skeletonSnapshot struct {
+	X       float32 `json:"x"`
+	Y       float32 `json:"y"`
+	OwnerID int     `json:"ownerID"`
+	Root    *Bone   `json:"root"`
+}
+
+// Snapshot serializes the whole simulation to versioned JSON.
+func (w *World) Snapshot() ([]byte, error) {
+	rngState, err := w.rng.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	snap := snapshot{
+		Version:      SnapshotVersion,
+		NextEntityID: w.nextEntityID,
+		NextSkelID:   w.nextSkelID,
+		NextAnimID:   w.nextAnimID,
+		Zombies:      w.Zombies,
+		Plants:       w.Plants,
+		Daves:        w.Daves,
+		Projectiles:  w.Projectiles,
+		Debris:       w.Debris,
+		Suns:         w.Suns,
+		Economy:      w.Economy,
+		Waves:        w.Waves,
+		Skeletons:    make(map[int]*skeletonSnapshot, len(w.Skeletons)),
+		Animations:   w.Animations,
+		Grid:         *w.Grid,
+		Clock:        w.Clock,
+		Seed:         w.Seed,
+		RNG:          rngState,
+		SelectedSeed: w.SelectedSeed,
+		Pending:      w.pending,
+	}
+	for id, s := range w.Skeletons {
+		snap.Skeletons[id] = &skeletonSnapshot{X: s.X, Y: s.Y, OwnerID: s.ownerID, Root: s.Root}
+	}
+	return json.Marshal(snap)
+}
+
+// Restore replaces the world's state with a snapshot produced by Snapshot.
+// On error the world is left untouched. It refuses while recording or
+// playing a replay: jumping back in time would leave the command log out of
+// step with the ticks it was recorded on.
+func (w *World) Restore(data []byte) error {
+	if w.recording != nil {
+		return fmt.Errorf("snapshot: can't restore while recording a replay")
+	}
+	if w.playback != nil {
+		return fmt.Errorf("snapshot: can't restore while playing a replay")
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("snapshot: %w", err)
+	}
+	if snap.Version != SnapshotVersion {
+		return fmt.Errorf("snapshot: version %d, expected %d", snap.Version, SnapshotVersion)
+	}
+
+	fresh := NewWorld()
+	fresh.nextEntityID = snap.NextEntityID
+	fresh.nextSkelID = snap.NextSkelID
+	fresh.nextAnimID = snap.NextAnimID
+	*fresh.Grid = snap.Grid
+	fresh.Clock = snap.Clock
+	fresh.SelectedSeed = snap.SelectedSeed
+	fresh.pending = snap.Pending
+
+	fresh.SetSeed(snap.Seed)
+	if err := fresh.rng.UnmarshalBinary(snap.RNG); err != nil {
+		return fmt.Errorf("snapshot: rng: %w", err)
+	}
+
+	for id, ss := range snap.Skeletons {
+		if id >= fresh.nextSkelID {
+			return fmt.Errorf("snapshot: skeleton %d is not below nextSkelID %d", id, fresh.nextSkelID)
+		}
+		s := NewSkeleton(ss.X, ss.Y)
+		s.ownerID = ss.OwnerID
+		if ss.Root != nil {
+			s.Root = ss.Root
+			s.relink(s.Root, nil)
+		}
+		s.Update(0)
+		fresh.Skeletons[id] = s
+	}
+	for id, a := range snap.Animations {
+		if id >= fresh.nextAnimID {
+			return fmt.Errorf("snapshot: animation %d is not below nextAnimID %d", id, fresh.nextAnimID)
+		}
+		fresh.Animations[id] = a
+	}
+
+	for id, z := range snap.Zombies {
+		if err := fresh.checkEntityID(id, z.ID); err != nil {
+			return err
+		}
+		if z.SkeletonID != 0 {
+			s, ok := fresh.Skeletons[z.SkeletonID]
+			if !ok {
+				return fmt.Errorf("snapshot: zombie %d references missing skeleton %d", id, z.SkeletonID)
+			}
+			z.Skeleton = s
+		}
+		fresh.Zombies[id] = z
+	}
+	for id, p := range snap.Plants {
+		if err := fresh.checkEntityID(id, p.ID); err != nil {
+			return err
+		}
+		if p.SkeletonID != 0 {
+			s, ok := fresh.Skeletons[p.SkeletonID]
+			if !ok {
+				return fmt.Errorf("snapshot: plant %d references missing skeleton %d", id, p.SkeletonID)
+			}
+			p.Skeleton = s
+		}
+		fresh.Plants[id] = p
+	}
+	for id, d := range snap.Daves {
+		if err := fresh.checkEntityID(id, d.ID); err != nil {
+			return err
+		}
+		s, ok := fresh.Skeletons[d.SkeletonID]
+		if !ok {
+			return fmt.Errorf("snapshot: dave %d references missing skeleton %d", id, d.SkeletonID)
+		}
+		d.Skeleton = s
+		fresh.Daves[id] = d
+	}
+
+	for id, pr := range snap.Projectiles {
+		if err := fresh.checkEntityID(id, pr.ID); err != nil {
+			return err
+		}
+		fresh.Projectiles[id] = pr
+	}
+
+	for id, d := range snap.Debris {
+		if id != d.SkeletonID {
+			return fmt.Errorf("snapshot: debris stored under %d has skeleton %d", id, d.SkeletonID)
+		}
+		if _, ok := fresh.Skeletons[id]; !ok {
+			return fmt.Errorf("snapshot: debris references missing skeleton %d", id)
+		}
+		fresh.Debris[id] = d
+	}
+
+	for id, s := range snap.Suns {
+		if err := fresh.checkEntityID(id, s.ID); err != nil {
+			return err
+		}
+		fresh.Suns[id] = s
+	}
+	fresh.Waves = snap.Waves
+	fresh.Economy = snap.Economy
+	if fresh.Economy.Recharge == nil {
+		fresh.Economy.Recharge = make(map[string]float32)
+	}
+
+	fresh.Registry = w.Registry
+	*w = *fresh
+	return nil
+}
+
+func (w *World) checkEntityID(key, id int) error {
+	if key != id {
+		return fmt.Errorf("snapshot: entity stored under %d has ID %d", key, id)
+	}
+	if id >= w.nextEntityID {
+		return fmt.Errorf("snapshot: entity %d is not below nextEntityID %d", id, w.nextEntityID)
+	}
+	return nil
+}
+
+// relink rebuilds the Bones index and Parent pointers, neither of which is
+// serialized.
+func (s *Skeleton) relink(b, parent *Bone) {
+	b.Parent = parent
+	s.Bones[b.Name] = b
+	for _, c := range b.Children {
+		s.relink(c, b)
+	}
+}
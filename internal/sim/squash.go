@@ -0,0 +1,141 @@
+package sim
+
+import (
+	"maps"
+	"slices"
+)
+
+// Squash attacks in stages, each one reported as an event and mirrored in
+// Plant.AnimState so renderers can pick the matching animation:
+//
+//	idle -> lock -> jump -> land -> removed
+//
+// It looks one cell ahead and one behind in its lane, locks onto the nearest
+// zombie, hops onto it and flattens everything in the cell it lands in.
+const (
+	AnimIdle = "idle"
+	AnimLock = "lock"
+	AnimJump = "jump"
+	AnimLand = "land"
+)
+
+// Stage durations in ms
+const (
+	squashLockTime = 500 // looks at the target before jumping
+	squashJumpTime = 400
+	squashLandTime = 300 // stays squished on the lawn, then disappears
+)
+
+func (w *World) updateSquash(p *Plant, dt float32) {
+	switch p.AnimState {
+	case AnimIdle:
+		z := w.squashTarget(p)
+		if z == nil {
+			return
+		}
+		p.AnimState = AnimLock
+		p.StateTimer = squashLockTime
+		p.TargetID = z.ID
+		p.TargetX = z.X
+		evt := plantEvent(EventSquashLock, p)
+		evt.Target = z.ID
+		w.emit(evt)
+
+	case AnimLock:
+		// Keep tracking the target until the jump; if it died meanwhile,
+		// jump where it was last seen
+		if z, ok := w.Zombies[p.TargetID]; ok && z.Health > 0 {
+			p.TargetX = z.X
+		}
+		p.StateTimer -= dt
+		if p.StateTimer > 0 {
+			return
+		}
+		p.AnimState = AnimJump
+		p.StateTimer = squashJumpTime
+		p.StartX = p.X
+		evt := plantEvent(EventSquashJump, p)
+		evt.X = p.TargetX
+		evt.Amount = squashJumpTime
+		evt.Target = p.TargetID
+		w.emit(evt)
+
+	case AnimJump:
+		p.StateTimer -= dt
+		t := 1 - max(p.StateTimer, 0)/squashJumpTime
+		p.X = p.StartX + (p.TargetX-p.StartX)*t
+		if p.StateTimer > 0 {
+			return
+		}
+		w.squashLand(p)
+
+	case AnimLand:
+		p.StateTimer -= dt
+		if p.StateTimer <= 0 {
+			w.emit(plantEvent(EventSquashDone, p))
+			w.DestroyPlant(p.ID)
+		}
+	}
+}
+
+// squashTarget is the nearest live zombie in p's lane whose hitbox reaches
+// into p's cell or the cells either side of it. Ties go to the one ahead.
+func (w *World) squashTarget(p *Plant) *Zombie {
+	cell := float32(w.Grid.CellSize)
+	left, right := p.X-cell, p.X+plantWidth+cell
+
+	var target *Zombie
+	var best float32
+	for _, id := range slices.Sorted(maps.Keys(w.Zombies)) {
+		z := w.Zombies[id]
+		if z.Row != p.Row || z.Health <= 0 {
+			continue
+		}
+		if z.X >= right || z.X+zombieWidth <= left {
+			continue
+		}
+		d := z.X - p.X
+		if d < 0 {
+			d = -d + 0.5 // behind loses ties
+		}
+		if target == nil || d < best {
+			target, best = z, d
+		}
+	}
+	return target
+}
+
+// squashLand damages every zombie overlapping the landing cell.
+func (w *World) squashLand(p *Plant) {
+	g := w.Grid
+	_, col := g.CellAt(float64(p.X+plantWidth/2), float64(p.Y+1))
+	left, right := p.X, p.X+plantWidth
+	if col >= 0 {
+		left = float32(g.StartX + float64(col)*g.CellSize)
+		right = left + float32(g.CellSize)
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(w.Zombies)) {
+		z := w.Zombies[id]
+		if z.Row != p.Row || z.Health <= 0 {
+			continue
+		}
+		if z.X >= right || z.X+zombieWidth <= left {
+			continue
+		}
+		w.damageZombie(z, p.Damage, DamageCrush)
+	}
+
+	p.AnimState = AnimLand
+	p.StateTimer = squashLandTime
+	evt := plantEvent(EventSquashLand, p)
+	evt.Col = col
+	evt.Amount = p.Damage
+	w.emit(evt)
+}
+
+// grounded reports whether p can be reached by zombies. A squash in the air
+// or already flattened can't be eaten.
+func (p *Plant) grounded() bool {
+	return p.Type != "squash" || p.AnimState == AnimIdle || p.AnimState == AnimLock
+}
@@ -0,0 +1,71 @@
+package sim
+
+import "testing"
+
+func TestSquashAttackSequence(t *testing.T) {
+	w := NewWorld()
+	p := plantIn(t, w, "squash", 2, 3)
+	cell := float32(w.Grid.CellSize)
+	z := zombieIn(t, w, "buckethead", 2, p.X+cell)
+	far := zombieIn(t, w, "basic", 2, p.X+3*cell)
+
+	var order []EventType
+	stages := map[EventType]bool{EventSquashLock: true, EventSquashJump: true, EventSquashLand: true, EventSquashDone: true}
+	for range 200 {
+		for _, e := range w.Advance(TickDT).Events {
+			if stages[e.Type] {
+				order = append(order, e.Type)
+			}
+			if e.Type == EventSquashLock && e.Target != z.ID {
+				t.Errorf("squash locked onto %d, want the near zombie %d", e.Target, z.ID)
+			}
+			if e.Type == EventSquashJump && p.grounded() {
+				t.Error("squash can still be eaten in the air")
+			}
+		}
+	}
+
+	want := []EventType{EventSquashLock, EventSquashJump, EventSquashLand, EventSquashDone}
+	if len(order) != len(want) {
+		t.Fatalf("stages = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("stages = %v, want %v", order, want)
+		}
+	}
+	if _, ok := w.Plants[p.ID]; ok {
+		t.Error("squash still planted after landing")
+	}
+	if z.Health > 0 {
+		t.Error("crushed zombie survived; armor should not soak a squash")
+	}
+	if far.Health != far.MaxHealth {
+		t.Error("zombie outside the landing cell was hit")
+	}
+}
+
+func TestSquashPrefersZombieAhead(t *testing.T) {
+	w := NewWorld()
+	p := plantIn(t, w, "squash", 2, 3)
+	behind := zombieIn(t, w, "basic", 2, p.X-40)
+	ahead := zombieIn(t, w, "basic", 2, p.X+40)
+
+	lock := runUntil(t, w, EventSquashLock, 1)[0]
+	if lock.Target != ahead.ID {
+		t.Errorf("locked onto %d, want %d ahead rather than %d behind at the same distance", lock.Target, ahead.ID, behind.ID)
+	}
+}
+
+func TestSquashIgnoresOtherLanes(t *testing.T) {
+	w := NewWorld()
+	p := plantIn(t, w, "squash", 2, 3)
+	zombieIn(t, w, "basic", 1, p.X)
+	for range 60 {
+		for _, e := range w.Advance(TickDT).Events {
+			if e.Type == EventSquashLock {
+				t.Fatal("squash locked onto a zombie in another lane")
+			}
+		}
+	}
+}
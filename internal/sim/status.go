@@ -0,0 +1,135 @@
+package sim
+
+// Status effects slow or stop a zombie for a while. A zombie keeps a list of
+// active effects; each one scales movement, gait animation and eat rate.
+//
+// Stacking rules:
+//   - Re-applying a kind that is already active refreshes it to the longer of
+//     the two remaining durations; it never stacks with itself.
+//   - Different kinds coexist. For each modifier the strongest (lowest)
+//     multiplier wins, so chill under freeze is still a full stop.
+
+type StatusKind string
+
+const (
+	StatusChill  StatusKind = "chill"  // snow pea: half speed, half eat rate
+	StatusFreeze StatusKind = "freeze" // ice: everything stops
+	StatusStun   StatusKind = "stun"   // stops walking and eating, animation plays on
+)
+
+// Snow pea chill, same as the old Zombie.applySlow(3000)
+const chillDuration = 3000
+
+type Status struct {
+	Kind      StatusKind `json:"kind"`
+	Remaining float32    `json:"remaining"` // ms
+}
+
+// statusModifiers are multipliers on Speed, WalkSpeed and eat rate.
+type statusModifiers struct {
+	Speed, Walk, Eat float32
+}
+
+var statusEffects = map[StatusKind]statusModifiers{
+	StatusChill:  {Speed: 0.5, Walk: 0.5, Eat: 0.5},
+	StatusFreeze: {Speed: 0, Walk: 0, Eat: 0},
+	StatusStun:   {Speed: 0, Walk: 1, Eat: 0},
+}
+
+// Tint is the colour a renderer should wash a zombie with. Only the
+// strongest tint is shown.
+type Tint string
+
+const (
+	TintNone   Tint = ""
+	TintChill  Tint = "chill"
+	TintFreeze Tint = "freeze"
+)
+
+// ApplyStatus adds or refreshes an effect on a zombie.
+func (w *World) ApplyStatus(z *Zombie, kind StatusKind, duration float32) {
+	if _, ok := statusEffects[kind]; !ok || duration <= 0 {
+		return
+	}
+
+	refreshed := false
+	for i := range z.Statuses {
+		if z.Statuses[i].Kind == kind {
+			z.Statuses[i].Remaining = max(z.Statuses[i].Remaining, duration)
+			refreshed = true
+		}
+	}
+	if !refreshed {
+		z.Statuses = append(z.Statuses, Status{Kind: kind, Remaining: duration})
+	}
+
+	evt := zombieEvent(EventStatusApplied, z)
+	evt.Kind = string(kind)
+	evt.Amount = duration
+	w.emit(evt)
+	w.updateTint(z)
+}
+
+// updateStatuses counts effects down and drops expired ones. Runs before the
+// zombie moves so an effect lasts exactly its duration.
+func (w *World) updateStatuses(z *Zombie, dt float32) {
+	if len(z.Statuses) == 0 {
+		return
+	}
+
+	kept := z.Statuses[:0]
+	for _, s := range z.Statuses {
+		s.Remaining -= dt
+		if s.Remaining > 0 {
+			kept = append(kept, s)
+			continue
+		}
+		evt := zombieEvent(EventStatusExpired, z)
+		evt.Kind = string(s.Kind)
+		w.emit(evt)
+	}
+	z.Statuses = kept
+	w.updateTint(z)
+}
+
+func (w *World) updateTint(z *Zombie) {
+	tint := TintNone
+	for _, s := range z.Statuses {
+		switch s.Kind {
+		case StatusFreeze:
+			tint = TintFreeze
+		case StatusChill:
+			if tint == TintNone {
+				tint = TintChill
+			}
+		}
+	}
+	if tint == z.Tint {
+		return
+	}
+	z.Tint = tint
+	evt := zombieEvent(EventZombieTint, z)
+	evt.Kind = string(tint)
+	w.emit(evt)
+}
+
+// modifiers combines all active effects.
+func (z *Zombie) modifiers() statusModifiers {
+	m := statusModifiers{Speed: 1, Walk: 1, Eat: 1}
+	for _, s := range z.Statuses {
+		e := statusEffects[s.Kind]
+		m.Speed = min(m.Speed, e.Speed)
+		m.Walk = min(m.Walk, e.Walk)
+		m.Eat = min(m.Eat, e.Eat)
+	}
+	return m
+}
+
+// onHitStatus is the effect a projectile of the given plant type leaves.
+func onHitStatus(kind string) (StatusKind, float32, bool) {
+	switch kind {
+	case "snowpea":
+		return StatusChill, chillDuration, true
+	}
+	return "", 0, false
+}
@@ -0,0 +1,75 @@
+package sim
+
+import (
+	"math"
+	"testing"
+)
+
+// walked returns how far z moves over one tick.
+func walked(w *World, z *Zombie) float32 {
+	x := z.X
+	w.Advance(TickDT)
+	return x - z.X
+}
+
+func TestChillHalvesSpeed(t *testing.T) {
+	w := NewWorld()
+	z := zombieIn(t, w, "basic", 2, 900)
+	full := walked(w, z)
+
+	w.ApplyStatus(z, StatusChill, 1000)
+	if got := walked(w, z); math.Abs(float64(got-full/2)) > 1e-3 {
+		t.Errorf("chilled zombie walked %v, want %v", got, full/2)
+	}
+	if z.Tint != TintChill {
+		t.Errorf("tint = %q, want chill", z.Tint)
+	}
+}
+
+func TestStatusRefreshAndStacking(t *testing.T) {
+	w := NewWorld()
+	z := zombieIn(t, w, "basic", 2, 900)
+
+	w.ApplyStatus(z, StatusChill, 1000)
+	w.ApplyStatus(z, StatusChill, 400)
+	if len(z.Statuses) != 1 || z.Statuses[0].Remaining != 1000 {
+		t.Fatalf("statuses = %+v, want one chill keeping the longer 1000ms", z.Statuses)
+	}
+
+	w.ApplyStatus(z, StatusFreeze, 500)
+	if z.Tint != TintFreeze {
+		t.Errorf("tint = %q, want freeze over chill", z.Tint)
+	}
+	if got := walked(w, z); got != 0 {
+		t.Errorf("frozen zombie walked %v", got)
+	}
+}
+
+func TestStatusExpires(t *testing.T) {
+	w := NewWorld()
+	z := zombieIn(t, w, "basic", 2, 900)
+	w.ApplyStatus(z, StatusStun, 10*TickDT)
+	w.PollEvents()
+
+	var expired []Event
+	for range 10 {
+		expired = append(expired, eventsOf(w.Advance(TickDT).Events, EventStatusExpired)...)
+	}
+	if len(expired) != 1 || expired[0].Kind != string(StatusStun) {
+		t.Fatalf("status_expired events = %+v, want the stun after exactly its duration", expired)
+	}
+	if len(z.Statuses) != 0 || walked(w, z) == 0 {
+		t.Error("zombie still stunned after the stun ran out")
+	}
+}
+
+func TestSnowPeaChills(t *testing.T) {
+	w := NewWorld()
+	plantIn(t, w, "snowpea", 2, 0)
+	z := zombieIn(t, w, "basic", 2, 700)
+
+	runUntil(t, w, EventProjectileHit, 600)
+	if len(z.Statuses) != 1 || z.Statuses[0].Kind != StatusChill {
+		t.Errorf("statuses = %+v, want a chill from the snow pea", z.Statuses)
+	}
+}
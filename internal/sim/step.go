@@ -0,0 +1,101 @@
+package sim
+
+import (
+	"maps"
+	"slices"
+)
+
+// EntityPos is the per-frame position of a moving entity.
+type EntityPos struct {
+	ID   int
+	X, Y float32
+}
+
+// StepResult summarises one Step so a bridge can hand everything to the
+// renderer in a single call instead of querying entity by entity.
+type StepResult struct {
+	Positions []EntityPos // zombies, daves, projectiles, then suns, each in ID order
+	Deaths    []int       // zombie IDs removed during this step (after their death sequence)
+	Events    []Event     // drained event buffer
+}
+
+// Step advances every registered entity and every free-standing skeleton by
+// dt milliseconds. Entities are visited in ID order so the outcome does not
+// depend on map iteration order. Gameplay should go through Advance, which
+// only ever calls Step with TickDT.
+func (w *World) Step(dt float32) StepResult {
+	var res StepResult
+
+	w.applyCommands()
+	if w.playback != nil && w.playback.done() {
+		w.playback = nil
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(w.Plants)) {
+		w.Plants[id].Update(w, dt)
+	}
+	w.updateEconomy(dt)
+	w.updateWaves(dt)
+
+	// Projectiles move before zombies so a shot fired this tick can't skip
+	// past a zombie that is about to walk into it
+	w.updateProjectiles(dt)
+	w.resolveEating(dt)
+
+	for _, id := range slices.Sorted(maps.Keys(w.Zombies)) {
+		z := w.Zombies[id]
+		if z.Health <= 0 && !z.Dying {
+			w.checkLimbs(z)
+		}
+		if z.Dying {
+			// zombie_died only once the collapse has played out
+			if z.updateDying(dt) {
+				w.emit(zombieEvent(EventZombieDied, z))
+				w.DestroyZombie(id)
+				res.Deaths = append(res.Deaths, id)
+				w.earnSun(zombieKillSun)
+			}
+			continue
+		}
+		w.updateStatuses(z, dt)
+		z.Update(dt)
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(w.Daves)) {
+		w.Daves[id].Update(dt)
+	}
+
+	w.updateDebris(dt)
+
+	// Entity skeletons were already updated by their owners above
+	for _, s := range w.Skeletons {
+		if s.ownerID == 0 {
+			s.Update(dt)
+		}
+	}
+
+	w.Clock.Tick++
+	res.Positions = w.appendPositions(nil)
+	res.Events = w.PollEvents()
+	return res
+}
+
+func (w *World) appendPositions(dst []EntityPos) []EntityPos {
+	for _, id := range slices.Sorted(maps.Keys(w.Zombies)) {
+		z := w.Zombies[id]
+		dst = append(dst, EntityPos{ID: id, X: z.X, Y: z.Y})
+	}
+	for _, id := range slices.Sorted(maps.Keys(w.Daves)) {
+		d := w.Daves[id]
+		dst = append(dst, EntityPos{ID: id, X: d.X, Y: d.Y})
+	}
+	for _, id := range slices.Sorted(maps.Keys(w.Projectiles)) {
+		pr := w.Projectiles[id]
+		dst = append(dst, EntityPos{ID: id, X: pr.X, Y: pr.Y})
+	}
+	for _, id := range slices.Sorted(maps.Keys(w.Suns)) {
+		s := w.Suns[id]
+		dst = append(dst, EntityPos{ID: id, X: s.X, Y: s.Y})
+	}
+	return dst
+}
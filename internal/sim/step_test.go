@@ -0,0 +1,54 @@
+package sim
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStepSummary(t *testing.T) {
+	w := NewWorld()
+	w.SetSkySunInterval(0)
+	// IDs interleave across kinds so the kind-then-ID order shows
+	sun := w.dropSun(300, 0, 400, sunValue, "sky")
+	back := zombieIn(t, w, "basic", 2, 800)
+	pr := w.fire(plantIn(t, w, "peashooter", 0, 0), 0)
+	dave := w.CreateDave(0, 0)
+	front := zombieIn(t, w, "basic", 1, 700)
+
+	res := w.Step(TickDT)
+	var ids []int
+	for _, pos := range res.Positions {
+		ids = append(ids, pos.ID)
+	}
+	if want := []int{back.ID, front.ID, dave.ID, pr.ID, sun.ID}; !slices.Equal(ids, want) {
+		t.Fatalf("positions in order %v, want zombies, daves, projectiles, suns: %v", ids, want)
+	}
+	if got := res.Positions[1]; got.X != front.X || got.Y != front.Y {
+		t.Errorf("zombie %d reported at (%v, %v), want (%v, %v)", front.ID, got.X, got.Y, front.X, front.Y)
+	}
+	if got := eventsOf(res.Events, EventSunSpawned); len(got) != 1 || got[0].ID != sun.ID {
+		t.Errorf("sun_spawned events %+v, want the one from before the step", got)
+	}
+	if len(w.PollEvents()) != 0 {
+		t.Error("Step left events in the buffer")
+	}
+
+	w.damageZombie(front, front.MaxHealth, DamageExplosion)
+	for range 1000 {
+		res = w.Step(TickDT)
+		if len(res.Deaths) > 0 {
+			break
+		}
+	}
+	if !slices.Equal(res.Deaths, []int{front.ID}) {
+		t.Fatalf("deaths = %v, want [%d]", res.Deaths, front.ID)
+	}
+	if len(eventsOf(res.Events, EventZombieDied)) != 1 {
+		t.Error("no zombie_died in the step that reported the death")
+	}
+	for _, pos := range res.Positions {
+		if pos.ID == front.ID {
+			t.Error("dead zombie still has a position")
+		}
+	}
+}
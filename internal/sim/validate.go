@@ -0,0 +1,222 @@
+package sim
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FieldError is one problem in a data file, at a JSON path such as
+// "peashooter.sunCost" or "levels[0].waves[2].spawns[1].type". An empty
+// Path means the file as a whole.
+type FieldError struct {
+	File    string `json:"file,omitempty"`
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	s := e.Message
+	if e.Path != "" {
+		s = e.Path + ": " + s
+	}
+	if e.File != "" {
+		s = e.File + ": " + s
+	}
+	return s
+}
+
+// FieldErrors is every problem found in a data file, one per line.
+type FieldErrors []FieldError
+
+func (errs FieldErrors) Error() string {
+	lines := make([]string, len(errs))
+	for i, e := range errs {
+		lines[i] = e.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// In marks errs as coming from file.
+func (errs FieldErrors) In(file string) FieldErrors {
+	for i := range errs {
+		errs[i].File = file
+	}
+	return errs
+}
+
+func (errs *FieldErrors) add(path, format string, args ...any) {
+	*errs = append(*errs, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// under prefixes every path in errs with parent.
+func (errs FieldErrors) under(parent string) FieldErrors {
+	for i := range errs {
+		errs[i].Path = joinPath(parent, errs[i].Path)
+	}
+	return errs
+}
+
+func joinPath(parent, field string) string {
+	switch {
+	case parent == "":
+		return field
+	case field == "" || field[0] == '[':
+		return parent + field
+	}
+	return parent + "." + field
+}
+
+func index(parent string, i int) string {
+	return parent + "[" + strconv.Itoa(i) + "]"
+}
+
+// decodeStrict decodes data into v, refusing fields v doesn't have and
+// anything after the value. Errors name the offending field where
+// encoding/json reports one, and every unknown field is listed by its full
+// path, not just the first.
+func decodeStrict(data []byte, v any) FieldErrors {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	err := dec.Decode(v)
+
+	var errs FieldErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case err == nil:
+		end := dec.InputOffset()
+		if _, err := dec.Token(); err != io.EOF {
+			errs.add("", "unexpected data after the JSON value ending at offset %d", end)
+			return errs
+		}
+	case errors.As(err, &typeErr):
+		// encoding/json still decodes the rest, so unknown fields are
+		// checked below as well
+		errs.add(fieldPath(typeErr.Field), "must be %s, not %s", jsonKind(typeErr.Type), typeErr.Value)
+	case errors.As(err, &syntaxErr):
+		errs.add("", "invalid JSON at offset %d: %v", syntaxErr.Offset, syntaxErr)
+		return errs
+	default:
+		errs.add("", "%v", err)
+		return errs
+	}
+
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		errs.add("", "%v", err)
+		return errs
+	}
+	unknownFields(raw, reflect.TypeOf(v), "", &errs)
+	return errs
+}
+
+var rawMessageType = reflect.TypeFor[json.RawMessage]()
+
+// unknownFields adds an error for every object key in raw that t has no
+// field for, the way DisallowUnknownFields would refuse it. Raw messages
+// are left to whoever decodes them.
+func unknownFields(raw any, t reflect.Type, path string, errs *FieldErrors) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == rawMessageType {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := raw.(map[string]any)
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for _, key := range slices.Sorted(maps.Keys(obj)) {
+			ft, ok := fields[key]
+			if !ok {
+				// encoding/json falls back to a case-insensitive match
+				for name, t := range fields {
+					if strings.EqualFold(name, key) {
+						ft, ok = t, true
+						break
+					}
+				}
+			}
+			if !ok {
+				errs.add(joinPath(path, key), "unknown field")
+				continue
+			}
+			unknownFields(obj[key], ft, joinPath(path, key), errs)
+		}
+	case reflect.Map:
+		obj, ok := raw.(map[string]any)
+		if !ok {
+			return
+		}
+		for _, key := range slices.Sorted(maps.Keys(obj)) {
+			unknownFields(obj[key], t.Elem(), joinPath(path, key), errs)
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := raw.([]any)
+		if !ok {
+			return
+		}
+		for i, elem := range arr {
+			unknownFields(elem, t.Elem(), index(path, i), errs)
+		}
+	}
+}
+
+// jsonFields maps the JSON names of t's fields, including those promoted
+// from embedded structs, to their types.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous && f.Tag.Get("json") == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// fieldPath turns encoding/json's dotted field (levels.0.delay) into the
+// levels[0].delay form the rest of the errors use.
+func fieldPath(dotted string) string {
+	var path string
+	for _, seg := range strings.Split(dotted, ".") {
+		if i, err := strconv.Atoi(seg); err == nil {
+			path = index(path, i)
+		} else {
+			path = joinPath(path, seg)
+		}
+	}
+	return path
+}
+
+// jsonKind names t the way the data files see it.
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct, reflect.Pointer:
+		return "an object"
+	}
+	return "a number"
+}
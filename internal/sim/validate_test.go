@@ -0,0 +1,67 @@
+package sim
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		path    string
+		message string // prefix
+	}{
+		{"valid", `{"version": 1, "levels": []}` + "\n", "", ""},
+		{
+			"type error in nested array",
+			`{"version": 1, "levels": [{"id": 1, "waves": [{"spawns": []}, {"spawns": [{"type": "basic", "delay": "soon"}]}]}]}`,
+			"levels[0].waves[1].spawns[0].delay", "must be a number, not string",
+		},
+		{"unknown field", `{"version": 1, "levels": [], "extra": true}`, "extra", "unknown field"},
+		{"trailing garbage", `{"version": 1, "levels": []} xx`, "", "unexpected data after the JSON value"},
+		{"second value", `{"version": 1, "levels": []}{}`, "", "unexpected data after the JSON value"},
+		{"syntax error", `{"version": 1,, "levels": []}`, "", "invalid JSON at offset"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f LevelFile
+			errs := decodeStrict([]byte(tt.in), &f)
+			if tt.message == "" {
+				if errs != nil {
+					t.Fatalf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("got %d errors (%v), want 1", len(errs), errs)
+			}
+			if errs[0].Path != tt.path {
+				t.Errorf("path = %q, want %q", errs[0].Path, tt.path)
+			}
+			if !strings.HasPrefix(errs[0].Message, tt.message) {
+				t.Errorf("message = %q, want it to start with %q", errs[0].Message, tt.message)
+			}
+		})
+	}
+}
+
+func TestDecodeStrictListsEveryUnknownField(t *testing.T) {
+	in := `{"version": 1, "extra": 0, "levels": [{"id": 1, "waves": [{"spawns": [{"type": "basic", "hp": 5}], "boss": true}]}, {"id": "two", "name": "x"}]}`
+	var f LevelFile
+	errs := decodeStrict([]byte(in), &f)
+
+	want := []string{"levels[1].id", "extra", "levels[0].waves[0].boss", "levels[0].waves[0].spawns[0].hp", "levels[1].name"}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Path)
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("paths = %v, want %v", got, want)
+	}
+	for _, e := range errs[1:] {
+		if e.Message != "unknown field" {
+			t.Errorf("%s: message = %q, want unknown field", e.Path, e.Message)
+		}
+	}
+}
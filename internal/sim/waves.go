@@ -0,0 +1,214 @@
+package sim
+
+import "fmt"
+
+// Port of WaveManager.js. A level is a list of waves; each wave waits its
+// start delay, spawns its zombies one by one with per-spawn delays, then
+// waits for the lawn to clear before the next wave. Everything runs on
+// simulation ticks, so the browser and native builds spawn on the same tick.
+
+type WaveState string
+
+const (
+	WaveStartDelay     WaveState = "START_DELAY"
+	WaveSpawning       WaveState = "SPAWNING"
+	WaveWaitingToClear WaveState = "WAITING_TO_CLEAR"
+	WaveComplete       WaveState = "COMPLETE"
+)
+
+// Defaults from WaveManager.js
+const (
+	firstWaveDelay = 2000 // ms before the first wave when it sets none
+	nextWaveDelay  = 5000 // ms between waves when the wave sets none
+
+	// Zombies enter off the right edge of the 1024px screen, 10px into the lane
+	zombieSpawnX       = 1024
+	zombieSpawnOffsetY = 10
+)
+
+type Spawn struct {
+	Type  string  `json:"type"`
+	Delay float32 `json:"delay"` // ms after the previous spawn (or wave start)
+}
+
+type Wave struct {
+	Spawns     []Spawn `json:"spawns"`
+	StartDelay float32 `json:"startDelay,omitempty"`
+	IsFlag     bool    `json:"isFlag,omitempty"`
+}
+
+type WaveManager struct {
+	Waves      []Wave    `json:"waves"`
+	Index      int       `json:"index"`
+	SpawnIndex int       `json:"spawnIndex"`
+	State      WaveState `json:"state"`
+	Timer      float32   `json:"timer"`
+	StartDelay float32   `json:"startDelay"`
+
+	// Set in endless mode, see endless.go. Waves then only holds the
+	// current wave, generated as it starts; Index still counts every wave.
+	Endless *EndlessConfig `json:"endless,omitempty"`
+}
+
+// current is the wave at Index.
+func (m *WaveManager) current() Wave {
+	if m.Endless != nil {
+		return m.Waves[0]
+	}
+	return m.Waves[m.Index]
+}
+
+// StartLevel replaces any running level with waves, starting from the first
+// wave's start delay.
+func (w *World) StartLevel(waves []Wave) error {
+	for i, wave := range waves {
+		if err := checkSpawns(wave.Spawns); err != nil {
+			return fmt.Errorf("wave %d %w", i, err)
+		}
+		for j, s := range wave.Spawns {
+			if _, ok := w.Registry.Zombies[s.Type]; !ok {
+				return fmt.Errorf("wave %d spawn %d: unknown zombie type %q", i, j, s.Type)
+			}
+		}
+	}
+
+	m := &WaveManager{Waves: waves, State: WaveStartDelay, StartDelay: firstWaveDelay}
+	if len(waves) > 0 && waves[0].StartDelay > 0 {
+		m.StartDelay = waves[0].StartDelay
+	}
+	if len(waves) == 0 {
+		m.State = WaveComplete
+	}
+	w.Waves = m
+	return nil
+}
+
+func (w *World) updateWaves(dt float32) {
+	m := w.Waves
+	if m == nil || m.State == WaveComplete {
+		return
+	}
+
+	if m.State == WaveStartDelay {
+		m.Timer += dt
+		if m.Timer >= m.StartDelay {
+			w.startWave()
+		}
+		return
+	}
+
+	if m.State == WaveSpawning {
+		m.Timer += dt
+		spawns := m.current().Spawns
+		if m.SpawnIndex < len(spawns) {
+			next := spawns[m.SpawnIndex]
+			if m.Timer >= next.Delay {
+				w.spawnWaveZombie(next.Type)
+				m.SpawnIndex++
+				// Delays are relative to the previous spawn
+				m.Timer = 0
+			}
+		} else {
+			m.State = WaveWaitingToClear
+		}
+	}
+
+	// Dying zombies are still in the map, so the next wave also waits for
+	// death sequences to finish
+	if m.State == WaveWaitingToClear && len(w.Zombies) == 0 {
+		w.nextWave()
+	}
+}
+
+func (w *World) startWave() {
+	m := w.Waves
+	if m.Endless != nil {
+		m.Waves = []Wave{w.endlessWave(m.Endless, m.Index)}
+	}
+	m.State = WaveSpawning
+	m.SpawnIndex = 0
+	m.Timer = 0
+
+	number := float32(m.Index + 1)
+	w.emit(Event{Type: EventWaveStarted, Row: -1, Col: -1, Amount: number})
+	if m.current().IsFlag {
+		w.emit(Event{Type: EventFlagWave, Row: -1, Col: -1, Amount: number})
+	}
+}
+
+func (w *World) spawnWaveZombie(typ string) {
+	row := w.RandomLane()
+	y := float32(w.Grid.StartY+float64(row)*w.Grid.CellSize) + zombieSpawnOffsetY
+	z := w.CreateZombie(typ, zombieSpawnX, y)
+	if z == nil {
+		return // registry swapped since StartLevel
+	}
+	w.emit(zombieEvent(EventZombieSpawned, z))
+}
+
+func (w *World) nextWave() {
+	m := w.Waves
+	m.Index++
+	if m.Endless != nil {
+		m.State = WaveStartDelay
+		m.Timer = 0
+		m.StartDelay = m.Endless.waveDelay(m.Index)
+		return
+	}
+	if m.Index >= len(m.Waves) {
+		m.State = WaveComplete
+		w.emit(Event{Type: EventLevelComplete, Row: -1, Col: -1})
+		return
+	}
+	m.State = WaveStartDelay
+	m.Timer = 0
+	m.StartDelay = nextWaveDelay
+	if d := m.Waves[m.Index].StartDelay; d > 0 {
+		m.StartDelay = d
+	}
+}
+
+// Progress is how far through the level we are, 0 to 1, for the progress
+// bar. Same formula as WaveManager.getProgress. In endless mode it is the
+// progress towards the next huge wave.
+func (m *WaveManager) Progress() float32 {
+	if m == nil {
+		return 0
+	}
+	n := float32(len(m.Waves))
+	progress := float32(m.Index) / n
+	if m.Endless != nil {
+		n = float32(m.Endless.WavesPerFlag)
+		progress = float32(m.Index%m.Endless.WavesPerFlag) / n
+	} else if len(m.Waves) == 0 {
+		return 0
+	}
+
+	switch m.State {
+	case WaveSpawning:
+		if spawns := len(m.current().Spawns); spawns > 0 {
+			progress += float32(m.SpawnIndex) / float32(spawns) / n
+		}
+	case WaveWaitingToClear:
+		// Almost done with this wave segment
+		progress += 0.99 / n
+	}
+	return min(1, progress)
+}
+
+// ProceduralWaves is getLevelConfig's fallback for levels without wave data:
+// five waves of basic zombies growing with the level number, the last one a
+// flag wave.
+func ProceduralWaves(levelID int) []Wave {
+	const waveCount = 5
+	waves := make([]Wave, waveCount)
+	for i := range waves {
+		count := 5 + levelID*2 + i
+		spawns := make([]Spawn, count)
+		for j := range spawns {
+			spawns[j] = Spawn{Type: "basic", Delay: 2000}
+		}
+		waves[i] = Wave{Spawns: spawns, StartDelay: 5000, IsFlag: i == waveCount-1}
+	}
+	return waves
+}
@@ -0,0 +1,110 @@
+package sim
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func clearLawn(w *World) {
+	for _, id := range slices.Sorted(maps.Keys(w.Zombies)) {
+		w.DestroyZombie(id)
+	}
+}
+
+// msToTicks is how many ticks it takes for ms to pass.
+func msToTicks(ms int) int {
+	return ms * TickRate / 1000
+}
+
+func TestWavesRunInOrder(t *testing.T) {
+	w := NewWorld()
+	w.SetSkySunInterval(0)
+	err := w.StartLevel([]Wave{
+		{StartDelay: 500, Spawns: []Spawn{{Type: "basic"}, {Type: "conehead", Delay: 300}}},
+		{StartDelay: 1000, IsFlag: true, Spawns: []Spawn{{Type: "football"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// First wave: its start delay, then one spawn right away and one 300ms on
+	var ticks []int
+	var kinds []string
+	for tick := 1; len(kinds) < 3 && tick < 200; tick++ {
+		for _, e := range w.Advance(TickDT).Events {
+			switch e.Type {
+			case EventWaveStarted, EventZombieSpawned:
+				ticks = append(ticks, tick)
+				kinds = append(kinds, e.Kind)
+			case EventFlagWave:
+				t.Error("first wave flagged")
+			}
+		}
+	}
+	if want := []string{"", "basic", "conehead"}; !slices.Equal(kinds, want) {
+		t.Fatalf("events %v, want the wave start then %v", kinds, want[1:])
+	}
+	if got := ticks[0]; got < msToTicks(500) || got > msToTicks(500)+1 {
+		t.Errorf("first wave started on tick %d, want %d", got, msToTicks(500))
+	}
+	if gap := ticks[2] - ticks[1]; gap < msToTicks(300) {
+		t.Errorf("second spawn %d ticks after the first, want at least %d", gap, msToTicks(300))
+	}
+
+	// The next wave waits for the lawn to clear
+	for range msToTicks(3000) {
+		if len(eventsOf(w.Advance(TickDT).Events, EventWaveStarted)) > 0 {
+			t.Fatal("second wave started with zombies on the lawn")
+		}
+	}
+	if w.Waves.State != WaveWaitingToClear {
+		t.Fatalf("state = %s, want %s", w.Waves.State, WaveWaitingToClear)
+	}
+	clearLawn(w)
+
+	waited := 0
+	for ; waited < 200; waited++ {
+		if len(eventsOf(w.Advance(TickDT).Events, EventFlagWave)) > 0 {
+			break
+		}
+	}
+	if waited < msToTicks(1000)-1 || waited >= 200 {
+		t.Fatalf("flag wave came %d ticks after the lawn cleared, want %d", waited, msToTicks(1000))
+	}
+	if w.Waves.Progress() >= 1 {
+		t.Error("progress full before the last wave is cleared")
+	}
+
+	runUntil(t, w, EventZombieSpawned, 10)
+	for range 10 {
+		w.Advance(TickDT)
+	}
+	clearLawn(w)
+	runUntil(t, w, EventLevelComplete, 1)
+	if w.Waves.State != WaveComplete || w.Waves.Progress() != 1 {
+		t.Errorf("state %s, progress %v: want the level complete", w.Waves.State, w.Waves.Progress())
+	}
+}
+
+func TestStartLevelRejectsUnknownZombie(t *testing.T) {
+	w := NewWorld()
+	err := w.StartLevel([]Wave{{Spawns: []Spawn{{Type: "basic"}, {Type: "ghost"}}}})
+	if err == nil {
+		t.Fatal("StartLevel accepted a ghost")
+	}
+	if w.Waves != nil {
+		t.Error("a rejected level replaced the waves")
+	}
+}
+
+func TestEmptyLevelIsComplete(t *testing.T) {
+	w := NewWorld()
+	if err := w.StartLevel(nil); err != nil {
+		t.Fatal(err)
+	}
+	w.Advance(TickDT)
+	if w.Waves.State != WaveComplete {
+		t.Errorf("state = %s, want %s", w.Waves.State, WaveComplete)
+	}
+}
@@ -0,0 +1,276 @@
+// Package sim is the platform-neutral game simulation shared by the wasm
+// bridge (src/game/wasm) and the native Ebiten client (internal/core).
+// Nothing in here may import syscall/js or ebiten.
+package sim
+
+import (
+	"maps"
+	"math/rand/v2"
+)
+
+// World owns every live entity, skeleton and animation plus the lawn grid.
+// The wasm bridge keeps a single World; the native client creates its own.
+type World struct {
+	// Entities
+	Zombies      map[int]*Zombie
+	Plants       map[int]*Plant
+	Daves        map[int]*Dave
+	Projectiles  map[int]*Projectile
+	nextEntityID int
+
+	// Falling body parts and armor, keyed by their skeleton ID
+	Debris map[int]*Debris
+
+	// Sun balance, collectable suns and seed recharge, see economy.go
+	Economy Economy
+	Suns    map[int]*Sun
+
+	// Running level, nil until StartLevel; see waves.go
+	Waves *WaveManager
+
+	// Skeletons are keyed separately from entities since the editor creates
+	// free-standing ones that belong to no entity.
+	Skeletons  map[int]*Skeleton
+	nextSkelID int
+
+	Animations map[int]*Animation
+	nextAnimID int
+
+	Grid  *Grid
+	Clock Clock
+
+	// Stats from plants.json and zombies.json, see LoadRegistry. Config,
+	// not state: kept across Restore and not part of snapshots.
+	Registry *Registry
+
+	// Seeded random source, see SetSeed
+	Seed uint64
+	Rand *rand.Rand
+	rng  *rand.PCG
+
+	// Events buffer, drained by PollEvents
+	events []Event
+
+	// Positions before the latest tick, for AdvanceResult.Previous
+	lastPositions []EntityPos
+
+	// Skeleton IDs in draw order, reused by AppendRenderBuffer
+	renderIDs []int
+
+	// Player input, see commands.go and replay.go
+	SelectedSeed string
+	pending      []Command
+	recording    *Replay
+	playback     *playback
+}
+
+func NewWorld() *World {
+	w := &World{
+		Zombies:      make(map[int]*Zombie),
+		Plants:       make(map[int]*Plant),
+		Daves:        make(map[int]*Dave),
+		Projectiles:  make(map[int]*Projectile),
+		Debris:       make(map[int]*Debris),
+		Economy:      newEconomy(),
+		Suns:         make(map[int]*Sun),
+		nextEntityID: 1,
+		Skeletons:    make(map[int]*Skeleton),
+		nextSkelID:   1,
+		Animations:   make(map[int]*Animation),
+		nextAnimID:   1,
+		Grid:         NewGrid(),
+		Registry:     DefaultRegistry(),
+	}
+	w.SetSeed(DefaultSeed)
+	return w
+}
+
+// --- Skeletons & Animations ---
+
+func (w *World) CreateSkeleton(x, y float32) int {
+	return w.RegisterSkeleton(NewSkeleton(x, y))
+}
+
+// RegisterSkeleton stores an existing skeleton (e.g. one owned by a zombie)
+// so it can be addressed by ID for bone setup and rendering.
+func (w *World) RegisterSkeleton(s *Skeleton) int {
+	id := w.nextSkelID
+	w.nextSkelID++
+	w.Skeletons[id] = s
+	return id
+}
+
+func (w *World) CreateAnimation(name string, duration float32) int {
+	id := w.nextAnimID
+	w.nextAnimID++
+
+	w.Animations[id] = &Animation{
+		Name:      name,
+		Duration:  duration,
+		Keyframes: []Keyframe{},
+	}
+	return id
+}
+
+// --- Entities ---
+
+func (w *World) newEntityID() int {
+	id := w.nextEntityID
+	w.nextEntityID++
+	return id
+}
+
+// CreateZombie returns nil for types missing from the registry.
+func (w *World) CreateZombie(typ string, x, y float32) *Zombie {
+	stats, ok := w.Registry.Zombies[typ]
+	if !ok {
+		return nil
+	}
+	z := NewZombie(w.newEntityID(), typ, x, y, stats)
+	// Zombies spawn off the right edge, so only the row is meaningful
+	z.Row, _ = w.Grid.CellAt(w.Grid.StartX, float64(y))
+	w.varyZombie(z)
+	w.Zombies[z.ID] = z
+
+	// Register Skeleton so JS can find it for bone updates during init
+	if z.Skeleton != nil {
+		z.Skeleton.ownerID = z.ID
+		z.SkeletonID = w.RegisterSkeleton(z.Skeleton)
+	}
+	return z
+}
+
+// CreatePlant returns nil for types missing from the registry.
+func (w *World) CreatePlant(typ string, x, y float32) *Plant {
+	stats, ok := w.Registry.Plants[typ]
+	if !ok {
+		return nil
+	}
+	p := NewPlant(w.newEntityID(), typ, x, y, stats)
+	p.Row, p.Col = w.Grid.CellAt(float64(x), float64(y))
+	p.Skeleton.ownerID = p.ID
+	p.SkeletonID = w.RegisterSkeleton(p.Skeleton)
+	w.Plants[p.ID] = p
+	return p
+}
+
+func (w *World) CreateDave(x, y float32) *Dave {
+	d := NewDave(w.newEntityID(), x, y)
+	w.Daves[d.ID] = d
+	d.Skeleton.ownerID = d.ID
+	d.SkeletonID = w.RegisterSkeleton(d.Skeleton)
+	return d
+}
+
+// --- Teardown ---
+//
+// Destroy* calls return false for unknown IDs so a double destroy from JS is
+// harmless. Entity skeletons are unregistered together with their owner.
+
+func (w *World) DestroyZombie(id int) bool {
+	z, ok := w.Zombies[id]
+	if !ok {
+		return false
+	}
+	if z.Skeleton != nil {
+		delete(w.Skeletons, z.SkeletonID)
+	}
+	delete(w.Zombies, id)
+	return true
+}
+
+func (w *World) DestroyPlant(id int) bool {
+	p, ok := w.Plants[id]
+	if !ok {
+		return false
+	}
+	if p.Skeleton != nil {
+		delete(w.Skeletons, p.SkeletonID)
+	}
+	delete(w.Plants, id)
+	return true
+}
+
+func (w *World) DestroyDave(id int) bool {
+	d, ok := w.Daves[id]
+	if !ok {
+		return false
+	}
+	delete(w.Skeletons, d.SkeletonID)
+	delete(w.Daves, id)
+	return true
+}
+
+func (w *World) DestroySkeleton(id int) bool {
+	if _, ok := w.Skeletons[id]; !ok {
+		return false
+	}
+	delete(w.Skeletons, id)
+	return true
+}
+
+func (w *World) DestroyAnimation(id int) bool {
+	if _, ok := w.Animations[id]; !ok {
+		return false
+	}
+	delete(w.Animations, id)
+	return true
+}
+
+// --- Runs ---
+
+// Reset ends the current run: every entity but Crazy Dave, the suns,
+// debris, waves, economy, queued input and any replay are dropped. Dave,
+// free-standing skeletons and animations belong to the page rather than the
+// run and stay, as do the registry, grid and seed. IDs keep counting up so
+// handles JS still holds can't alias new entities.
+func (w *World) Reset() {
+	*w = *w.cleared()
+}
+
+// cleared is a new world holding only what Reset keeps.
+func (w *World) cleared() *World {
+	fresh := NewWorld()
+	fresh.Registry = w.Registry
+	fresh.nextEntityID = w.nextEntityID
+	fresh.nextSkelID = w.nextSkelID
+	fresh.nextAnimID = w.nextAnimID
+	*fresh.Grid = *w.Grid
+	fresh.SetSeed(w.Seed)
+
+	maps.Copy(fresh.Daves, w.Daves)
+	for id, s := range w.Skeletons {
+		_, dave := w.Daves[s.ownerID]
+		_, debris := w.Debris[id]
+		if dave || (s.ownerID == 0 && !debris) {
+			fresh.Skeletons[id] = s
+		}
+	}
+	maps.Copy(fresh.Animations, w.Animations)
+	return fresh
+}
+
+// Counts is a snapshot of registry sizes, used to spot leaks in long runs.
+type Counts struct {
+	Zombies     int `json:"zombies"`
+	Plants      int `json:"plants"`
+	Daves       int `json:"daves"`
+	Projectiles int `json:"projectiles"`
+	Suns        int `json:"suns"`
+	Skeletons   int `json:"skeletons"`
+	Debris      int `json:"debris"`
+	Animations  int `json:"animations"`
+}
+
+func (w *World) Counts() Counts {
+	return Counts{
+		Zombies:     len(w.Zombies),
+		Plants:      len(w.Plants),
+		Daves:       len(w.Daves),
+		Projectiles: len(w.Projectiles),
+		Suns:        len(w.Suns),
+		Skeletons:   len(w.Skeletons),
+		Debris:      len(w.Debris),
+		Animations:  len(w.Animations),
+	}
+}
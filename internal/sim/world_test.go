@@ -0,0 +1,69 @@
+package sim
+
+import "testing"
+
+func TestCreateRegistersEntitySkeletons(t *testing.T) {
+	w := NewWorld()
+	z := zombieIn(t, w, "basic", 1, 700)
+	p := plantIn(t, w, "peashooter", 1, 0)
+	d := w.CreateDave(0, 0)
+
+	owned := map[int]int{z.SkeletonID: z.ID, p.SkeletonID: p.ID, d.SkeletonID: d.ID}
+	if len(owned) != 3 {
+		t.Fatalf("entities share skeleton IDs: %v", owned)
+	}
+	for skelID, owner := range owned {
+		s, ok := w.Skeletons[skelID]
+		if !ok {
+			t.Errorf("skeleton %d of entity %d not registered", skelID, owner)
+			continue
+		}
+		if s.ownerID != owner {
+			t.Errorf("skeleton %d owned by %d, want %d", skelID, s.ownerID, owner)
+		}
+	}
+	if z.Row != 1 || p.Row != 1 || p.Col != 0 {
+		t.Errorf("zombie row %d, plant cell (%d, %d): want row 1, cell (1, 0)", z.Row, p.Row, p.Col)
+	}
+
+	if w.CreateZombie("ghost", 0, 0) != nil || w.CreatePlant("ghost", 0, 0) != nil {
+		t.Error("created an entity of an unknown type")
+	}
+}
+
+func TestDestroyLeavesNothingBehind(t *testing.T) {
+	w := NewWorld()
+	z := zombieIn(t, w, "basic", 1, 700)
+	p := plantIn(t, w, "peashooter", 1, 0)
+	d := w.CreateDave(0, 0)
+	skel := w.CreateSkeleton(10, 10)
+	anim := w.CreateAnimation("wave", 1000)
+
+	want := Counts{Zombies: 1, Plants: 1, Daves: 1, Skeletons: 4, Animations: 1}
+	if got := w.Counts(); got != want {
+		t.Fatalf("counts = %+v, want %+v", got, want)
+	}
+
+	destroys := []struct {
+		name    string
+		destroy func(int) bool
+		id      int
+	}{
+		{"zombie", w.DestroyZombie, z.ID},
+		{"plant", w.DestroyPlant, p.ID},
+		{"dave", w.DestroyDave, d.ID},
+		{"skeleton", w.DestroySkeleton, skel},
+		{"animation", w.DestroyAnimation, anim},
+	}
+	for _, tt := range destroys {
+		if !tt.destroy(tt.id) {
+			t.Errorf("destroying %s %d failed", tt.name, tt.id)
+		}
+		if tt.destroy(tt.id) {
+			t.Errorf("%s %d destroyed twice", tt.name, tt.id)
+		}
+	}
+	if got := w.Counts(); got != (Counts{}) {
+		t.Errorf("counts after destroying everything = %+v, want none", got)
+	}
+}
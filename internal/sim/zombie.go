@@ -0,0 +1,123 @@
+package sim
+
+import "math"
+
+type Zombie struct {
+	ID       int
+	Type     string
+	X, Y     float32
+	Row      int // Lane, resolved from Y on creation
+	Health   float32
+	Speed    float32
+	Damage   float32 // per 60 Hz tick, see biteDamage
+	IsEating bool
+	TargetID int // plant being eaten while IsEating
+
+	AnimTime  float32
+	WalkSpeed float32
+
+	Armor    *Armor   `json:",omitempty"` // nil once broken or for unarmored types
+	Statuses []Status // active effects, see status.go
+
+	// Death sequence, see death.go
+	LostArm    bool
+	Dying      bool
+	DeathTimer float32
+	Tint       Tint // render wash from Statuses
+
+	Skeleton *Skeleton `json:"-"` // Restored from SkeletonID in snapshots
+
+	// Stats
+	MaxHealth float32
+
+	SkeletonID int // Optimization: Store ID to avoid O(N) lookup
+}
+
+// NewZombie builds a zombie of a known type with the given stats, see
+// Registry. Armored types get the armor's own pool on top of Health, see
+// armor.go.
+func NewZombie(id int, typeStr string, x, y float32, stats ZombieStats) *Zombie {
+	z := &Zombie{
+		ID:        id,
+		Type:      typeStr,
+		X:         x,
+		Y:         y,
+		IsEating:  false,
+		WalkSpeed: 0.005,
+		Health:    stats.Health,
+		MaxHealth: stats.Health,
+		Speed:     stats.Speed,
+		Damage:    stats.Damage,
+	}
+
+	z.Skeleton = newZombieSkeleton(x, y, typeStr)
+	if a := stats.Armor; a != nil {
+		z.wearArmor(a.Kind, a.Health)
+	}
+
+	return z
+}
+
+// newZombieSkeleton builds the same bone tree as Zombie.initSkeleton in JS.
+// Image IDs follow ImageIDMap in Skeleton.js.
+func newZombieSkeleton(x, y float32, typ string) *Skeleton {
+	scale := float32(0.1)
+	if typ == "boss" {
+		scale = 0.2
+	}
+
+	s := NewSkeleton(x, y)
+	s.AddBone("", &Bone{Name: "torso", ImageID: 2, PivotX: 343, PivotY: 458, ScaleX: scale, ScaleY: scale})
+	s.AddBone("torso", &Bone{Name: "head", ImageID: 1, LocalY: -400, PivotX: 386, PivotY: 750, ScaleX: 1, ScaleY: 1})
+	s.AddBone("torso", &Bone{Name: "lArm", ImageID: 3, LocalX: -150, LocalY: -350, PivotX: 200, PivotY: 100, ScaleX: 1, ScaleY: 1})
+	s.AddBone("torso", &Bone{Name: "rArm", ImageID: 3, LocalX: 150, LocalY: -350, PivotX: 200, PivotY: 100, ScaleX: 1, ScaleY: 1})
+	s.AddBone("torso", &Bone{Name: "lLeg", ImageID: 4, LocalX: -100, LocalY: 350, PivotX: 400, PivotY: 50, ScaleX: 1, ScaleY: 1})
+	s.AddBone("torso", &Bone{Name: "rLeg", ImageID: 4, LocalX: 100, LocalY: 350, PivotX: 400, PivotY: 50, ScaleX: 1, ScaleY: 1})
+	return s
+}
+
+func (z *Zombie) Update(dt float32) {
+	mod := z.modifiers()
+	if z.IsEating {
+		z.animateEat(dt * mod.Walk)
+	} else {
+		z.X -= z.Speed * mod.Speed * dt
+		z.animateWalk(dt * mod.Walk)
+	}
+
+	// Update Skeleton Position
+	if z.Skeleton != nil {
+		z.Skeleton.X = z.X + 50 // Offset for centering roughly
+		z.Skeleton.Y = z.Y + 70 // Offset from JS
+		z.Skeleton.Update(dt)
+	}
+}
+
+// Procedural Animations ported from JS
+func (z *Zombie) animateWalk(dt float32) {
+	z.AnimTime += dt * z.WalkSpeed
+	t := z.AnimTime
+
+	z.setRot("head", float32(math.Sin(float64(t))*0.1))
+	z.setRot("lArm", float32(math.Sin(float64(t))*0.5))
+	z.setRot("rArm", float32(math.Sin(float64(t)+math.Pi)*0.5))
+	z.setRot("lLeg", float32(math.Sin(float64(t))*0.6))
+	z.setRot("rLeg", float32(math.Sin(float64(t)+math.Pi)*0.6))
+}
+
+func (z *Zombie) animateEat(dt float32) {
+	z.AnimTime += dt * 0.01
+	t := z.AnimTime * 10
+
+	z.setRot("head", float32(math.Abs(math.Sin(float64(t)))*0.2))
+	z.setRot("lArm", 1.0+float32(math.Sin(float64(t))*0.1))
+}
+
+func (z *Zombie) setRot(boneName string, rot float32) {
+	if z.Skeleton == nil {
+		return
+	}
+	if b, ok := z.Skeleton.Bones[boneName]; ok {
+		b.Rotation = rot
+	}
+}
@@ -6,6 +6,7 @@ const ClientAPI = {
         "levels.json",
         "plants.json",
         "zombies.json",
+        "endless.json",
         // Add other JSON files found in public/data
     ],
 
@@ -67,6 +68,18 @@ const ClientAPI = {
                 body: content
             });
             if (response.ok) serverSaved = true;
+            // Rejected by the data file schema: nothing was written, and the
+            // local backup would only hide the problem
+            if (response.status === 422) {
+                let errors;
+                try {
+                    ({ errors } = await response.json());
+                } catch (e) { }
+                if (!Array.isArray(errors)) {
+                    errors = [{ path: '', message: 'The server rejected the file but its reply could not be read' }];
+                }
+                return { savedTo: 'none', message: `Not saved: ${errors.length} problem(s)`, errors };
+            }
         } catch (e) { }
 
         // Always save to LocalStorage as backup/primary for client mode
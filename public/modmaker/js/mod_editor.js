@@ -2,6 +2,7 @@
 export class ModEditor {
     constructor() {
         this.editorEl = document.getElementById('json-editor');
+        this.errorsEl = document.getElementById('json-errors');
         this.currentFilename = null;
 
         // Auto-save or Cmd+S listener could go here
@@ -20,6 +21,7 @@ export class ModEditor {
         try {
             const data = await window.ClientAPI.read(filename);
             this.editorEl.value = JSON.stringify(data, null, 4);
+            this.showErrors([]);
             document.getElementById('status-left').textContent = `Editing ${filename}`;
         } catch (e) {
             this.editorEl.value = "// Error loading file: " + e.message;
@@ -45,9 +47,54 @@ export class ModEditor {
             const result = await window.ClientAPI.save(this.currentFilename, content);
 
             document.getElementById('status-left').textContent = result.message;
-            setTimeout(() => document.getElementById('status-left').textContent = 'Ready', 2000);
+            this.showErrors(result.errors || []);
+            if (!result.errors) {
+                setTimeout(() => document.getElementById('status-left').textContent = 'Ready', 2000);
+            }
         } catch (e) {
             alert("Save failed: " + e.message);
         }
     }
+
+    // List schema errors from the server; clicking one selects the field
+    showErrors(errors) {
+        if (!this.errorsEl) return;
+        this.errorsEl.innerHTML = '';
+        errors.forEach(err => {
+            const item = document.createElement('div');
+            item.className = 'json-error';
+            item.textContent = err.path ? `${err.path}: ${err.message}` : err.message;
+            item.onclick = () => {
+                const [start, end] = locateField(this.editorEl.value, err.path);
+                this.editorEl.focus();
+                this.editorEl.setSelectionRange(start, end);
+            };
+            this.errorsEl.appendChild(item);
+        });
+    }
+}
+
+// locateField finds the key a path like "levels[0].waves[2].type" points
+// at in pretty-printed JSON. An index skips that many occurrences of the
+// next key, which is right for arrays of objects. Dotted indexes
+// (levels.0.type) are read the same way.
+function locateField(text, path) {
+    const parts = (path || '').match(/[^.[\]]+|\[\d+\]/g) || [];
+    let start = 0, end = 0, skip = 0;
+    for (const part of parts) {
+        if (part.startsWith('[') || /^\d+$/.test(part)) {
+            skip = parseInt(part.replace('[', ''), 10);
+            continue;
+        }
+        const key = `"${part}"`;
+        let idx = text.indexOf(key, start);
+        for (; skip > 0 && idx !== -1; skip--) {
+            idx = text.indexOf(key, idx + key.length);
+        }
+        if (idx === -1) break;
+        start = idx;
+        end = idx + key.length;
+        skip = 0;
+    }
+    return [start, end];
 }
@@ -104,7 +104,10 @@ export class CrazyDave {
     appear() {
         this.visible = true;
         this.x = -200;
-        // Slide in
+        // Slide in; Go only updates and draws Dave once he has appeared
+        if (this.id !== undefined && window.daveAppear) {
+            window.daveAppear(this.id);
+        }
     }
 
     speak(text) {
@@ -117,7 +120,7 @@ export class CrazyDave {
     update(dt) {
         if (!this.visible) return;
 
-        if (this.id !== undefined && window.updateDave) {
+        if (this.id !== undefined && !this.game.wasmStep && window.updateDave) {
             window.updateDave(this.id, dt);
         }
 
@@ -1,10 +1,13 @@
 export class DataLoader {
     static async loadAllData() {
-        const [levels, plants, zombies] = await Promise.all([
+        const [levels, plants, zombies, endless] = await Promise.all([
             fetch('data/levels.json').then(r => r.json()),
             fetch('data/plants.json').then(r => r.json()),
-            fetch('data/zombies.json').then(r => r.json())
+            fetch('data/zombies.json').then(r => r.json()),
+            fetch('data/endless.json').then(r => r.json())
         ]);
-        return { levels, plants, zombies };
+        // levels.json is versioned ({version, levels}); bare arrays are the
+        // pre-migration shape (see cmd/pvz-levels)
+        return { levels: Array.isArray(levels) ? levels : levels.levels, plants, zombies, endless };
     }
 }
@@ -4,6 +4,8 @@ import { Plant } from './Plant.js';
 import { Zombie } from './Zombie.js';
 import { Projectile } from './Projectile.js';
 import { AssetLoader } from './graphics/AssetLoader.js';
+import { RenderBuffer } from './graphics/RenderBuffer.js';
+import { WasmSkeleton } from './graphics/Skeleton.js';
 import { Sun } from './Sun.js';
 import { CrazyDave } from './CrazyDave.js';
 import { getLevelConfig } from './LevelConfig.js';
@@ -13,6 +15,9 @@ import { SaveManager } from './SaveManager.js';
 import { WaveManager } from './WaveManager.js';
 import { FogManager } from './FogManager.js';
 
+// Must match sim.EventProtocolVersion in internal/sim/events.go
+const EVENT_PROTOCOL_VERSION = 1;
+
 export class Game {
     constructor(canvas) {
         this.canvas = canvas;
@@ -33,6 +38,9 @@ export class Game {
         // Load Game Data
         DataLoader.loadAllData().then(data => {
             this.gameData = data;
+            if (window.loadRegistry) {
+                window.loadRegistry(JSON.stringify(data.plants), JSON.stringify(data.zombies));
+            }
             this.isLoaded = true;
             // Initialize game state specific things that depend on data if any
             // For now, we just mark as loaded, but we might want to refresh level config
@@ -46,10 +54,7 @@ export class Game {
 
         this.zombiesSpawned = 0;
         this.zombiesKilled = 0;
-        this.isEndless = false;
-        this.endlessWave = 1;
-        this.endlessTimer = 0;
-        this.endlessNextWaveTime = 30000; // 30s per wave ramp up (doesn't stop spawning, just gets harder)
+        this.isEndless = false; // Go generates the waves, see internal/sim/endless.go
 
         this.sun = 100;
         this.grid = new Grid(this);
@@ -59,12 +64,17 @@ export class Game {
         this.zombies = [];
         this.projectiles = [];
         this.suns = [];
+        this.explosions = []; // Visual only, from Go explode events
+        this.debris = new Map(); // Go debris skeleton ID -> WasmSkeleton
 
         this.skySunTimer = 0;
         this.skySunInterval = 10000; // 10s
 
         this.crazyDave = new CrazyDave(this);
 
+        // Batched skeleton data from Go, refreshed once per frame in draw()
+        this.renderBuffer = new RenderBuffer();
+
         // Initialize level config for safety, though reset() handles it
         // We defer this until data load usually, but keeping it for safety
         this.currentLevelConfig = getLevelConfig(this.level);
@@ -73,9 +83,24 @@ export class Game {
     reset() {
         this.state = 'PLAYING';
         this.sun = 100;
+
+        // Release Go-side entities from the previous run
+        this.zombies.forEach(z => z.destroy());
+        if (this.grid) {
+            this.grid.cells.forEach(row => row.forEach(cell => {
+                if (cell.plant) cell.plant.destroy();
+                if (cell.basePlant) cell.basePlant.destroy();
+            }));
+        }
+        // Go keeps suns, shots, debris and recharge timers until told
+        if (window.resetWorld) {
+            window.resetWorld();
+        }
         this.zombies = [];
         this.suns = [];
         this.projectiles = [];
+        this.explosions = [];
+        this.debris = new Map();
 
         // Load Level Config first to get grid dimensions
         // Note: we usually load this from gameData, but for restart we need to be sure.
@@ -93,6 +118,19 @@ export class Game {
 
         this.zombiesSpawned = 0;
         this.zombiesKilled = 0;
+        this.checkpointState = null; // Only good for the run it was taken in
+
+        // Seed the Go RNG per run; keep it so the run can be reproduced
+        this.seed = this.fixedSeed !== undefined ? this.fixedSeed : Math.floor(Math.random() * 2 ** 31);
+        if (window.setSeed) {
+            window.setSeed(this.seed);
+        }
+        console.log(`Level seed: ${this.seed}`);
+        // Go owns the sun balance; stepWorld reports changes as sun_balance
+        if (window.setSun) {
+            window.setSun(this.sun);
+            window.setSkySunInterval(this.skySunInterval);
+        }
 
         // Load Level Config
         if (this.gameData) {
@@ -101,12 +139,22 @@ export class Game {
             this.currentLevelConfig = getLevelConfig(this.level);
         }
 
-        // Initialize Wave Manager
-        this.waveManager = new WaveManager(this, this.currentLevelConfig.waves);
+        // Go runs the waves and spawns zombies when it can (zombie_spawned
+        // events); WaveManager.js is the fallback without wasm
+        this.waveManager = null;
+        let goWaves;
+        if (this.isEndless && window.startEndless && this.gameData) {
+            goWaves = window.startEndless(JSON.stringify(this.gameData.endless));
+        } else {
+            goWaves = window.startLevel && window.startLevel(JSON.stringify(this.currentLevelConfig.waves));
+        }
+        if (!goWaves) {
+            this.waveManager = new WaveManager(this, this.currentLevelConfig.waves);
+        }
 
-        if (this.isEndless) {
-            this.endlessWave = 1;
-            this.endlessTimer = 0;
+        // After reset, seed, sun and level so the replay holds everything it needs
+        if (this.recordingReplay && window.startRecording) {
+            window.startRecording();
         }
 
         // Hide screens
@@ -122,6 +170,9 @@ export class Game {
         this.reset();
         this.state = 'ZEN_GARDEN';
         this.sun = 1000; // Give plenty of sun for gardening
+        if (window.setSun) window.setSun(this.sun);
+        if (window.setSkySunInterval) window.setSkySunInterval(0); // No sky sun either
+        if (window.startLevel) window.startLevel('[]'); // No zombies in the garden
 
         // Load Plants
         const gardenData = SaveManager.loadGarden();
@@ -183,6 +234,9 @@ export class Game {
 
                 div.addEventListener('click', () => {
                     this.selectedPlant = plantType;
+                    if (window.submitCommand) {
+                        window.submitCommand({ type: 'select_seed', kind: plantType });
+                    }
                     document.querySelectorAll('.seed-packet').forEach(p => p.style.border = '2px solid var(--glass-border)');
                     div.style.border = '2px solid var(--primary)';
                 });
@@ -191,9 +245,26 @@ export class Game {
         }
     }
 
+    // Grey out packets the player can't afford or that are still recharging
+    updateSeedPackets() {
+        if (!window.getSeedStatus || this.state === 'ZEN_GARDEN') return;
+        document.querySelectorAll('.seed-packet').forEach(div => {
+            const status = window.getSeedStatus(div.dataset.plant);
+            if (!status) return;
+            const cost = div.querySelector('.seed-cost');
+            if (cost) cost.textContent = status.cost;
+            div.style.opacity = status.affordable && status.ready ? 1 : 0.5;
+            // Recharge shown as a shade sliding off the packet
+            const pct = status.cooldown > 0 ? (status.recharge / status.cooldown) * 100 : 0;
+            div.style.backgroundImage = pct > 0 ? `linear-gradient(rgba(0,0,0,0.5) ${pct}%, transparent ${pct}%)` : '';
+        });
+    }
+
     gameOver() {
         this.state = 'GAME_OVER';
         document.getElementById('game-over-screen').classList.remove('hidden');
+        const checkpointBtn = document.getElementById('checkpoint-btn');
+        if (checkpointBtn) checkpointBtn.classList.toggle('hidden', !this.checkpointState);
     }
 
     levelComplete() {
@@ -220,6 +291,10 @@ export class Game {
                 if (this.gameData && this.gameData.plants[this.selectedPlant]) {
                     cost = this.gameData.plants[this.selectedPlant].sunCost;
                 }
+                // Go also checks the seed recharge; it rejects the command anyway
+                // if either check fails by the time it applies
+                const status = window.getSeedStatus ? window.getSeedStatus(this.selectedPlant) : null;
+                const canBuy = status ? status.affordable && status.ready : this.sun >= cost;
 
                 if (this.state === 'ZEN_GARDEN') {
                     // Always free or check sun?
@@ -227,7 +302,7 @@ export class Game {
                         cell.plant = new Plant(this, cell.x, cell.y, this.selectedPlant);
                         this.saveZenGarden();
                     }
-                } else if (this.sun >= cost) {
+                } else if (canBuy) {
                     // Pool Logic
                     const isWater = this.grid.isWater(gridPos.row);
                     const newPlantMock = { type: this.selectedPlant }; // Quick check helper or just string check
@@ -239,21 +314,18 @@ export class Game {
                         if (isAquatic) {
                             // Can plant if no base plant (or maybe replace? usually no)
                             if (!cell.basePlant) {
-                                this.sun -= cost;
-                                cell.basePlant = new Plant(this, cell.x, cell.y, this.selectedPlant);
+                                this.placePlant(cell, cost);
                             }
                         } else {
                             // Land plant on water -> Needs Lily Pad
                             if (cell.basePlant && cell.basePlant.canPlantOnTop && !cell.plant) {
-                                this.sun -= cost;
-                                cell.plant = new Plant(this, cell.x, cell.y, this.selectedPlant);
+                                this.placePlant(cell, cost);
                             }
                         }
                     } else {
                         // Grass
                         if (!isAquatic && !cell.plant) {
-                            this.sun -= cost;
-                            cell.plant = new Plant(this, cell.x, cell.y, this.selectedPlant);
+                            this.placePlant(cell, cost);
                         }
                     }
                 }
@@ -261,6 +333,133 @@ export class Game {
         }
     }
 
+    // With wasm, placement is a Go command so it gets recorded for replays;
+    // the plant itself is attached when the plant_placed event comes back.
+    placePlant(cell, cost) {
+        if (window.submitCommand) {
+            window.submitCommand({ type: 'place_plant', row: cell.row, col: cell.col, kind: this.selectedPlant });
+            return;
+        }
+        this.sun -= cost;
+        this.attachPlant(cell, this.selectedPlant);
+    }
+
+    attachPlant(cell, type, wasmID) {
+        const plant = new Plant(this, cell.x, cell.y, type, wasmID);
+        if (plant.isAquatic) {
+            cell.basePlant = plant;
+        } else {
+            cell.plant = plant;
+        }
+        return plant;
+    }
+
+    getPlantCost(type) {
+        if (this.gameData && this.gameData.plants[type]) {
+            return this.gameData.plants[type].sunCost;
+        }
+        return 0;
+    }
+
+    // Replays are recorded on demand: recordReplay() restarts the level
+    // with recording on, exportReplay() ends it and hands back the JSON
+    recordReplay() {
+        this.recordingReplay = true;
+        this.reset();
+    }
+
+    exportReplay() {
+        this.recordingReplay = false;
+        return window.stopRecording ? window.stopRecording() : null;
+    }
+
+    // Whole simulation state as JSON, for a checkpoint or a bug report
+    dumpState() {
+        return window.saveSnapshot ? window.saveSnapshot() : null;
+    }
+
+    // Quick retries: checkpoint() remembers this moment of the level,
+    // retryCheckpoint() puts the lawn back to it
+    checkpoint() {
+        this.checkpointState = this.dumpState();
+        return this.checkpointState !== null;
+    }
+
+    retryCheckpoint() {
+        return this.checkpointState ? this.loadState(this.checkpointState) : false;
+    }
+
+    // Load a dumpState() string taken on this level back into Go, then
+    // rebuild the JS objects around the wasm IDs the snapshot kept
+    loadState(json) {
+        if (!window.restoreSnapshot || !window.restoreSnapshot(json)) return false;
+
+        // The old Go entities went with the old world and their IDs now
+        // belong to restored ones, so drop the JS side without destroy()
+        this.zombies = [];
+        this.projectiles = [];
+        this.suns = [];
+        this.explosions = [];
+        this.debris = new Map();
+        this.grid.cells.forEach(row => row.forEach(cell => {
+            cell.plant = null;
+            cell.basePlant = null;
+        }));
+        this.wasmStep = null;
+
+        const entities = window.getEntities();
+        this.sun = entities.sun;
+        entities.plants.forEach(p => {
+            const cell = this.grid.getCell(p.row, p.col);
+            if (!cell) return;
+            const plant = this.attachPlant(cell, p.type, p.id);
+            plant.health = p.health;
+            plant.damageTier = p.damageTier;
+            plant.skin = p.skin;
+            plant.isArmed = p.isArmed;
+            plant.animState = p.animState;
+            if (p.animState === 'jump') {
+                plant.jump = { fromX: p.x, toX: p.targetX, time: 0, duration: p.stateTimer };
+            }
+        });
+        entities.zombies.forEach(e => {
+            const z = new Zombie(this, e.y, e.type, e.id);
+            z.x = e.x;
+            z.health = e.health;
+            z.maxHealth = e.maxHealth;
+            z.isEating = e.isEating;
+            z.isDying = e.isDying;
+            z.tint = e.tint;
+            this.zombies.push(z);
+        });
+        entities.suns.forEach(e => {
+            const sun = new Sun(this, e.x, e.y, e.targetY, e.id);
+            sun.value = e.value;
+            this.suns.push(sun);
+        });
+        entities.projectiles.forEach(e => {
+            const type = e.kind === 'snowpea' ? 'frozen' : 'normal';
+            this.projectiles.push(new Projectile(this, e.x, e.y, type, e.id));
+        });
+        entities.debris.forEach(e => this.debris.set(e.id, new WasmSkeleton(e.x, e.y, e.id)));
+
+        // Retrying from the game over screen picks the level back up
+        if (this.state !== 'ZEN_GARDEN') {
+            this.state = 'PLAYING';
+            document.querySelectorAll('.screen').forEach(el => el.classList.add('hidden'));
+        }
+        this.lastTime = performance.now();
+        return true;
+    }
+
+    playReplay(json) {
+        this.recordingReplay = false;
+        this.reset();
+        if (window.playReplay) {
+            window.playReplay(json);
+        }
+    }
+
     saveZenGarden() {
         const plants = [];
         for (let r = 0; r < this.grid.rows; r++) {
@@ -297,18 +496,22 @@ export class Game {
     }
 
     update(dt) {
+        // 0. Advance every Go-side entity in one boundary crossing
+        this.stepWasmWorld(dt);
+
         // 1. Update Plants
         for (let r = 0; r < this.grid.rows; r++) {
             for (let c = 0; c < this.grid.cols; c++) {
                 const cell = this.grid.cells[r][c];
                 if (cell.plant) {
                     if (cell.plant.markedForDeletion) {
+                        cell.plant.destroy();
                         cell.plant = null;
                     } else {
                         cell.plant.update(dt);
 
-                        // Squash Logic
-                        if (cell.plant.type === 'squash') {
+                        // Squash Logic (Go runs its own, see internal/sim/squash.go)
+                        if (cell.plant.type === 'squash' && cell.plant.id === undefined) {
                             // Check for zombies in same cell
                             for (const z of this.zombies) {
                                 if (!z.markedForDeletion && Math.abs(z.y - cell.plant.y) < 50) { // Same row roughly
@@ -329,31 +532,17 @@ export class Game {
         }
 
         // 2. Spawn Zombies
-        if (this.state === 'ZEN_GARDEN') return; // No Zombies in Zen Garden
-
-        if (this.isEndless) {
-            // Endless Mode Logic - KEEP EXISTING LOGIC FOR NOW OR MIGRATE LATER
-            this.endlessTimer += dt;
-            // ... (keep endless logic mostly separate for now or refactor to use WaveManager too)
-            // For now, let's keep the old endless logic block but adapt variables
-            // Ideally, Endless would just be an infinite WaveManager? 
-            // Let's stick to the prompt scope: "Add A Wave System", mostly implying normal levels.
-            // But I removed this.zombieSpawnTimer from constructor. I should re-add it if endless needs it.
-            // Or better, let's make endless use WaveManager but with generated waves?
-            // See LevelConfig.js fallback. It generates waves.
-            // So if I use WaveManager for everything, I don't need this block?
-            // But Endless logic had "ramp up".
-            // Let's assume LevelConfig fallback covers "Endless" for levels > defined.
-            // BUT "isEndless" flag is explicit.
-
-            if (this.waveManager) {
-                this.waveManager.update(dt);
-            }
-        } else {
-            // Normal Level Logic using WaveManager
-            if (this.waveManager) {
-                this.waveManager.update(dt);
-            }
+        if (this.state === 'ZEN_GARDEN') {
+            // No Zombies in Zen Garden, but the step's events (suns, eaten or
+            // exploded plants) still have to reach the lawn
+            this.handleWasmEvents();
+            return;
+        }
+
+        // Endless mode only differs in the waves Go generates; without
+        // wasm it plays the level config like a normal level
+        if (this.waveManager) {
+            this.waveManager.update(dt);
         }
 
         // 3. Update Zombies
@@ -364,31 +553,48 @@ export class Game {
 
         // 5. Update Sun
         this.suns.forEach(s => s.update(dt));
+        this.explosions.forEach(e => e.timer -= dt);
 
         // Spawn Sky Sun
-        this.skySunTimer += dt;
+        // Go drops sky sun itself when stepping
+        if (!this.wasmStep) this.skySunTimer += dt;
         if (this.skySunTimer > this.skySunInterval) {
             this.skySunTimer = 0;
-            this.spawnSun(Math.random() * (this.width - 50) + 25, -50, Math.random() * (this.height - 200) + 100);
+            if (window.randomSkySun) {
+                const pos = window.randomSkySun();
+                this.spawnSun(pos.x, -50, pos.y);
+            } else {
+                this.spawnSun(Math.random() * (this.width - 50) + 25, -50, Math.random() * (this.height - 200) + 100);
+            }
         }
 
         this.checkCollisions();
 
         // 6. Cleanup
-        this.zombies = this.zombies.filter(z => !z.markedForDeletion);
+        this.zombies = this.zombies.filter(z => {
+            if (z.markedForDeletion) {
+                z.destroy();
+                return false;
+            }
+            return true;
+        });
         this.projectiles = this.projectiles.filter(p => !p.markedForDeletion);
         this.suns = this.suns.filter(s => !s.markedForDeletion);
+        this.explosions = this.explosions.filter(e => e.timer > 0);
 
         // 7. Update UI
         if (sunDisplay) {
             sunDisplay.innerText = Math.floor(this.sun);
         }
+        this.updateSeedPackets();
 
         // Wave Progress
         const waveBar = document.getElementById('wave-progress-bar');
         if (waveBar && this.waveManager) {
             const pct = this.waveManager.getProgress() * 100;
             waveBar.style.width = pct + '%';
+        } else if (waveBar && window.getWaveProgress) {
+            waveBar.style.width = (window.getWaveProgress() * 100) + '%';
         }
 
         if (this.crazyDave) this.crazyDave.update(dt);
@@ -412,34 +618,211 @@ export class Game {
         this.handleWasmEvents();
     }
 
+    stepWasmWorld(dt) {
+        this.wasmStep = null;
+        if (!window.stepWorld) return;
+
+        // Go runs fixed ticks internally; alpha is how far we are into the next one
+        this.wasmStep = window.stepWorld(dt);
+        const alpha = this.wasmStep.alpha;
+
+        // positions is packed [id, x, y, prevX, prevY, ...] and reused by Go
+        // across frames, so only the first count entries are current; draw
+        // between the last two ticks so motion stays smooth when frames and
+        // ticks don't line up
+        this.wasmPositions = new Map();
+        this.wasmPositionsY = new Map();
+        const pos = this.wasmStep.positions;
+        for (let i = 0; i < this.wasmStep.count * 5; i += 5) {
+            this.wasmPositions.set(pos[i], pos[i + 3] + (pos[i + 1] - pos[i + 3]) * alpha);
+            this.wasmPositionsY.set(pos[i], pos[i + 4] + (pos[i + 2] - pos[i + 4]) * alpha);
+        }
+
+        // Zombies Go has already removed
+        if (this.wasmStep.deaths.length > 0) {
+            const dead = new Set(this.wasmStep.deaths);
+            for (const z of this.zombies) {
+                if (z.id !== undefined && dead.has(z.id)) {
+                    z.id = undefined; // Go side is gone already
+                    z.markedForDeletion = true; // Kill reward comes as sun_balance
+                }
+            }
+        }
+    }
+
     handleWasmEvents() {
-        if (!window.pollEvents) return;
-        const events = window.pollEvents();
+        let events;
+        if (this.wasmStep) {
+            events = this.wasmStep.events;
+        } else if (window.pollEvents) {
+            events = window.pollEvents();
+        }
         if (!events || events.length === 0) return;
 
+        if (!this.checkedEventProtocol) {
+            this.checkedEventProtocol = true;
+            const goVersion = window.getEventProtocolVersion ? window.getEventProtocolVersion() : 0;
+            if (goVersion !== EVENT_PROTOCOL_VERSION) {
+                console.error(`Wasm event protocol v${goVersion}, Game.js expects v${EVENT_PROTOCOL_VERSION}`);
+            }
+        }
+
         events.forEach(evt => {
+            if (evt.type === 'plant_placed') {
+                const cell = this.grid.getCell(evt.row, evt.col);
+                if (cell) {
+                    this.attachPlant(cell, evt.kind, evt.id);
+                }
+                return;
+            }
+            if (evt.type === 'projectile_spawned') {
+                const type = evt.kind === 'snowpea' ? 'frozen' : 'normal';
+                this.projectiles.push(new Projectile(this, evt.x, evt.y, type, evt.id));
+                return;
+            }
+            if (evt.type === 'projectile_hit' || evt.type === 'projectile_expired') {
+                const p = this.projectiles.find(p => p.wasmID === evt.id);
+                if (p) p.markedForDeletion = true;
+                if (evt.type === 'projectile_hit') {
+                    // JS health pools armor and body, so the whole hit comes off;
+                    // the boss bar reads it
+                    const z = this.zombies.find(z => z.id === evt.target);
+                    if (z) z.health -= evt.amount;
+                }
+                return;
+            }
+            if (evt.type === 'plant_eaten') {
+                const cell = this.grid.getCell(evt.row, evt.col);
+                for (const key of ['plant', 'basePlant']) {
+                    if (cell && cell[key] && cell[key].id === evt.id) {
+                        // Already gone in Go, don't destroy it again
+                        cell[key].id = undefined;
+                        cell[key] = null;
+                    }
+                }
+                return;
+            }
+            if (evt.type === 'zombie_eat_start' || evt.type === 'zombie_eat_stop') {
+                const z = this.zombies.find(z => z.id === evt.id);
+                if (z) z.isEating = evt.type === 'zombie_eat_start';
+                return;
+            }
+            if (evt.type === 'explode') {
+                const cell = this.grid.getCell(evt.row, evt.col);
+                if (cell && cell.plant && cell.plant.id === evt.id) {
+                    // Already gone in Go, don't destroy it again
+                    cell.plant.id = undefined;
+                    cell.plant = null;
+                }
+                this.explosions.push({ x: evt.x + 40, y: evt.y + 40, kind: evt.kind, timer: 500 });
+                return;
+            }
+            if (evt.type.startsWith('squash_')) {
+                const cell = this.grid.getCell(evt.row, evt.col);
+                const plant = this.grid.cells.flat().map(c => c.plant).find(p => p && p.id === evt.id);
+                if (!plant) return;
+                if (evt.type === 'squash_lock') {
+                    plant.animState = 'lock';
+                } else if (evt.type === 'squash_jump') {
+                    plant.animState = 'jump';
+                    plant.jump = { fromX: plant.x, toX: evt.x, time: 0, duration: evt.amount };
+                } else if (evt.type === 'squash_land') {
+                    plant.animState = 'land';
+                    plant.jump = null;
+                    plant.x = evt.x;
+                    this.explosions.push({ x: evt.x + 40, y: evt.y + 40, kind: 'squash', timer: 300 });
+                } else if (evt.type === 'squash_done' && cell && cell.plant === plant) {
+                    // Already gone in Go, don't destroy it again
+                    plant.id = undefined;
+                    cell.plant = null;
+                }
+                return;
+            }
+            if (evt.type === 'plant_damaged') {
+                const plant = this.grid.cells.flat().flatMap(c => [c.plant, c.basePlant]).find(p => p && p.id === evt.id);
+                if (plant) {
+                    plant.damageTier = evt.amount;
+                    plant.skin = evt.kind;
+                }
+                return;
+            }
+            if (evt.type === 'zombie_spawned') {
+                this.zombies.push(new Zombie(this, evt.y, evt.kind, evt.id));
+                this.zombiesSpawned++;
+                return;
+            }
+            if (evt.type === 'wave_started') {
+                console.log(`Starting Wave ${evt.amount}`);
+                return;
+            }
+            if (evt.type === 'flag_wave') {
+                this.showHugeWaveMessage();
+                return;
+            }
+            if (evt.type === 'level_complete') {
+                this.levelComplete();
+                return;
+            }
+            if (evt.type === 'sun_balance') {
+                this.sun = evt.amount;
+                return;
+            }
+            if (evt.type === 'sun_spawned') {
+                const sun = new Sun(this, evt.x, evt.y, evt.y, evt.id);
+                sun.value = evt.amount;
+                this.suns.push(sun);
+                return;
+            }
+            if (evt.type === 'sun_collected' || evt.type === 'sun_expired') {
+                const sun = this.suns.find(s => s.wasmID === evt.id);
+                if (sun) sun.markedForDeletion = true;
+                return;
+            }
+            if (evt.type === 'zombie_dying') {
+                // Go plays the collapse; stepWorld reports the death once it's over
+                const z = this.zombies.find(z => z.id === evt.id);
+                if (z) z.isDying = true;
+                return;
+            }
+            if (evt.type === 'armor_dropped' || evt.type === 'limb_lost') {
+                if (evt.target) this.debris.set(evt.target, new WasmSkeleton(evt.x, evt.y, evt.target));
+                return;
+            }
+            if (evt.type === 'debris_removed') {
+                this.debris.delete(evt.id);
+                return;
+            }
+            if (evt.type === 'zombie_tint') {
+                const z = this.zombies.find(z => z.id === evt.id);
+                if (z) z.tint = evt.kind;
+                return;
+            }
+            if (evt.type === 'plant_removed') {
+                const cell = this.grid.getCell(evt.row, evt.col);
+                if (cell && cell.plant && cell.plant.id === evt.id) {
+                    cell.plant = null;
+                } else if (cell && cell.basePlant && cell.basePlant.id === evt.id) {
+                    cell.basePlant = null;
+                }
+                return;
+            }
+
             let plant = null;
-            // Search grid for plant target
+            // Plant events carry their cell, see internal/sim/events.go
             if (evt.type === 'shoot' || evt.type === 'spawn_sun' || evt.type === 'arm') {
-                for (let r = 0; r < this.grid.rows; r++) {
-                    for (let c = 0; c < this.grid.cols; c++) {
-                        const p = this.grid.cells[r][c].plant;
-                        if (p && p.id === evt.id) {
-                            plant = p;
-                            break;
-                        }
-                    }
-                    if (plant) break;
+                const cell = this.grid.getCell(evt.row, evt.col);
+                if (cell && cell.plant && cell.plant.id === evt.id) {
+                    plant = cell.plant;
                 }
             }
 
             if (plant) {
-                if (evt.type === 'shoot') {
-                    // Trigger existing shoot logic which adds Projectile to game
-                    // We trust Go says "shoot now"
+                if (evt.type === 'shoot' && !this.wasmStep) {
+                    // Without stepWorld Go can't report projectile positions,
+                    // so fall back to JS projectiles
                     plant.shoot();
                 }
-                if (evt.type === 'spawn_sun') {
+                if (evt.type === 'spawn_sun' && !this.wasmStep) {
                     // Standard sunflower logic
                     // We might need to call specific method if plant.spawnSun exists?
                     // Plant.js usually has update logic for sun.
@@ -450,7 +833,7 @@ export class Game {
                     // If it was inline in `update`, I need to extract it or perform it here.
                     // I'll assume I can add or call `spawnSun`.
                     // Actually, I'll add `spawnSun` to Plant.js if missing or just do it here:
-                    this.spawnSun(plant.x, plant.y, plant.y + 40);
+                    this.spawnSun(plant.x, plant.y, plant.y + 40, evt.amount);
                 }
                 if (evt.type === 'arm') {
                     plant.isArmed = true;
@@ -459,11 +842,18 @@ export class Game {
         });
     }
 
-    spawnSun(x, y, toY) {
-        this.suns.push(new Sun(this, x, y, toY));
+    spawnSun(x, y, toY, value) {
+        const sun = new Sun(this, x, y, toY);
+        if (value) sun.value = value;
+        this.suns.push(sun);
     }
 
     collectSun(sun) {
+        // Go banks its own suns; sun_collected removes it next step
+        if (sun.wasmID !== undefined && window.submitCommand) {
+            window.submitCommand({ type: 'collect_sun', id: sun.wasmID });
+            return;
+        }
         this.sun += sun.value;
         sun.markedForDeletion = true;
     }
@@ -482,9 +872,22 @@ export class Game {
         });
     }
 
+    drawExplosion(e) {
+        // Cherry bomb covers 3x3 cells, a potato mine its own cell
+        const radius = e.kind === 'cherrybomb' ? 150 : 50;
+        this.ctx.save();
+        this.ctx.globalAlpha = Math.max(0, e.timer / 500);
+        this.ctx.fillStyle = '#f97316';
+        this.ctx.beginPath();
+        this.ctx.arc(e.x, e.y, radius * (1.2 - e.timer / 2500), 0, Math.PI * 2);
+        this.ctx.fill();
+        this.ctx.restore();
+    }
+
     checkCollisions() {
         // 1. Projectiles vs Zombies
         for (const p of this.projectiles) {
+            if (p.wasmID !== undefined) continue; // Resolved in Go
             for (const z of this.zombies) {
                 if (!p.markedForDeletion && !z.markedForDeletion) {
                     if (this.checkCollision(p, z)) {
@@ -508,6 +911,8 @@ export class Game {
         // 2. Zombies vs Plants
         for (const z of this.zombies) {
             if (z.markedForDeletion) continue;
+            // Go resolves eating and potato mines for its zombies
+            if (z.id !== undefined && this.wasmStep) continue;
 
             let hitPlant = false;
 
@@ -565,12 +970,21 @@ export class Game {
         // Draw Grid
         this.grid.draw(this.ctx);
 
+        // One Go call for every skeleton drawn below
+        this.renderBuffer.fetch();
+
         // Draw Zombies
         this.zombies.forEach(z => z.draw(this.ctx));
 
         // Draw Projectiles
         this.projectiles.forEach(p => p.draw(this.ctx));
 
+        // Draw Debris (Go moves it, we only draw)
+        this.debris.forEach(d => d.draw(this.ctx));
+
+        // Draw Explosions
+        this.explosions.forEach(e => this.drawExplosion(e));
+
         // Draw Sun
         this.suns.forEach(s => s.draw(this.ctx));
 
@@ -9,6 +9,29 @@ export class Input {
         // Bind listeners
         this.canvas.addEventListener('mousemove', (e) => this.onMouseMove(e));
         this.canvas.addEventListener('mousedown', (e) => this.onMouseDown(e));
+        window.addEventListener('keydown', (e) => this.onKeyDown(e));
+    }
+
+    // F6 sets a checkpoint, F9 goes back to it (from game over too), and
+    // F8 downloads a dump of the simulation state to attach to bug reports
+    onKeyDown(e) {
+        if (e.key === 'F6' && this.game.state === 'PLAYING') {
+            if (this.game.checkpoint()) e.preventDefault();
+            return;
+        }
+        if (e.key === 'F9' && (this.game.state === 'PLAYING' || this.game.state === 'GAME_OVER')) {
+            if (this.game.retryCheckpoint()) e.preventDefault();
+            return;
+        }
+        if (e.key !== 'F8' || this.game.state !== 'PLAYING') return;
+        const json = this.game.dumpState();
+        if (!json) return;
+        e.preventDefault();
+        const link = document.createElement('a');
+        link.href = URL.createObjectURL(new Blob([json], { type: 'application/json' }));
+        link.download = `pvz-state-${this.game.seed}.json`;
+        link.click();
+        URL.revokeObjectURL(link.href);
     }
 
     getMousePos(e) {
@@ -28,6 +51,8 @@ export class Input {
 
     onMouseDown(e) {
         if (this.game.state !== 'PLAYING') return;
+        // Replays drive all input until they finish
+        if (window.isReplaying && window.isReplaying()) return;
 
         const pos = this.getMousePos(e);
 
@@ -4,7 +4,8 @@ import { AssetLoader } from './graphics/AssetLoader.js';
 import { WasmLoader } from './graphics/WasmLoader.js';
 
 export class Plant extends Entity {
-    constructor(game, x, y, type) {
+    // wasmID is passed when Go already created the plant (placement commands)
+    constructor(game, x, y, type, wasmID) {
         super(game, x, y);
         this.type = type;
         this.health = 100;
@@ -17,7 +18,9 @@ export class Plant extends Entity {
 
         // Wasm Init
         const useWasm = WasmLoader.instance && WasmLoader.instance.isReady && window.createPlant;
-        if (useWasm) {
+        if (wasmID !== undefined) {
+            this.id = wasmID;
+        } else if (useWasm) {
             this.id = window.createPlant(type, x, y);
         }
 
@@ -55,12 +58,29 @@ export class Plant extends Entity {
         // Repeater sub-timer
         this.shotsFired = 0;
         this.burstTimer = 0;
+
+        // Wear tier from Go plant_damaged events
+        this.damageTier = 0;
+        this.skin = '';
+
+        // Squash stages, driven by Go events
+        this.animState = 'idle';
+        this.jump = null;
     }
 
     update(deltaTime) {
-        if (this.id !== undefined && window.updatePlant) {
-            window.updatePlant(this.id, deltaTime);
+        if (this.id !== undefined && (this.game.wasmStep || window.updatePlant)) {
+            // Timers are advanced by stepWorld when available
+            if (!this.game.wasmStep) window.updatePlant(this.id, deltaTime);
             this.idleTime += deltaTime * 0.002; // Keep visual animation running
+            if (this.jump) {
+                // Squash hop, tweened from the squash_jump event
+                const j = this.jump;
+                j.time = Math.min(j.time + deltaTime, j.duration);
+                const t = j.time / j.duration;
+                this.x = j.fromX + (j.toX - j.fromX) * t;
+                this.hopY = Math.sin(t * Math.PI) * 80;
+            }
             return;
         }
 
@@ -153,6 +173,14 @@ export class Plant extends Entity {
         }
     }
 
+    // Release the Go-side plant once it leaves the lawn
+    destroy() {
+        if (this.id !== undefined && window.destroyPlant) {
+            window.destroyPlant(this.id);
+            this.id = undefined;
+        }
+    }
+
     explode() {
         this.markedForDeletion = true;
         this.game.createExplosion(this.x + this.width / 2, this.y + this.height / 2);
@@ -187,6 +215,7 @@ export class Plant extends Entity {
             ctx.beginPath();
             ctx.arc(this.x + this.width / 2, this.y + this.height / 2, 30, 0, Math.PI * 2);
             ctx.fill();
+            this.drawCracks(ctx); // Tall-nut, pumpkin
         }
     }
 
@@ -302,14 +331,34 @@ export class Plant extends Entity {
     }
 
     drawWallnut(ctx) {
-        const img = AssetLoader.getImage('wallnut');
+        // Skin comes from the damage states in plants.json
+        const img = (this.skin && AssetLoader.getImage(this.skin)) || AssetLoader.getImage('wallnut');
         if (!img) return;
 
-        // Damage states (visualized by tint or just shake?)
-        // For now just draw image
         ctx.save();
         ctx.drawImage(img, this.x, this.y, this.width, this.height);
         ctx.restore();
+        if (!(this.skin && AssetLoader.getImage(this.skin))) this.drawCracks(ctx);
+    }
+
+    // Stand-in wear for skins without their own art: one crack per tier
+    drawCracks(ctx) {
+        if (this.damageTier <= 0) return;
+        ctx.save();
+        ctx.strokeStyle = 'rgba(40, 20, 0, 0.8)';
+        ctx.lineWidth = 2;
+        const cx = this.x + this.width / 2;
+        const cy = this.y + this.height / 2;
+        for (let i = 0; i < this.damageTier; i++) {
+            const dir = i % 2 === 0 ? 1 : -1;
+            ctx.beginPath();
+            ctx.moveTo(cx + dir * 5, cy - 25 + i * 10);
+            ctx.lineTo(cx - dir * 8, cy - 10 + i * 10);
+            ctx.lineTo(cx + dir * 6, cy + 5 + i * 10);
+            ctx.lineTo(cx - dir * 4, cy + 20 + i * 10);
+            ctx.stroke();
+        }
+        ctx.restore();
     }
 
     drawRepeater(ctx) {
@@ -333,12 +382,16 @@ export class Plant extends Entity {
 
     drawSquash(ctx) {
         const img = AssetLoader.getImage('squash');
+        // animState comes from Go: idle, lock, jump, land
+        const y = this.y - (this.animState === 'jump' ? this.hopY || 0 : 0);
+        const h = this.animState === 'land' ? this.height / 3 : this.height;
+        const top = y + this.height - h;
         if (img) {
-            ctx.drawImage(img, this.x, this.y, this.width, this.height);
+            ctx.drawImage(img, this.x, top, this.width, h);
         } else {
             // Fallback
             ctx.fillStyle = '#f97316';
-            ctx.fillRect(this.x + 10, this.y + 10, this.width - 20, this.height - 20);
+            ctx.fillRect(this.x + 10, top + 10, this.width - 20, h - 20);
         }
     }
 
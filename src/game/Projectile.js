@@ -1,7 +1,8 @@
 import { Entity } from './Entity.js';
 
 export class Projectile extends Entity {
-    constructor(game, x, y, type = 'normal') {
+    // wasmID is set when Go simulates this projectile; we only draw it then
+    constructor(game, x, y, type = 'normal', wasmID) {
         super(game, x, y);
         this.width = 20;
         this.height = 20;
@@ -10,9 +11,16 @@ export class Projectile extends Entity {
         this.markedForDeletion = false;
         this.type = type;
         this.freeze = type === 'frozen';
+        this.wasmID = wasmID;
     }
 
     update(deltaTime) {
+        if (this.wasmID !== undefined) {
+            const x = this.game.wasmPositions && this.game.wasmPositions.get(this.wasmID);
+            if (x !== undefined) this.x = x;
+            return;
+        }
+
         this.x += this.speed * deltaTime;
 
         if (this.x > this.game.width) {
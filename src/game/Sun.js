@@ -1,7 +1,9 @@
 
 export class Sun {
-    constructor(game, x, y, toY) {
+    // wasmID is set for suns Go owns; they move and expire in Go
+    constructor(game, x, y, toY, wasmID) {
         this.game = game;
+        this.wasmID = wasmID;
         this.x = x;
         this.y = y;
         this.toY = toY !== undefined ? toY : y; // Defaults to staying put if no dest
@@ -19,12 +21,19 @@ export class Sun {
 
     update(dt) {
         this.life += dt;
-        if (this.life > this.maxLife) {
-            this.markedForDeletion = true;
-        }
+        if (this.wasmID !== undefined && this.game.wasmPositions) {
+            if (this.game.wasmPositions.has(this.wasmID)) {
+                this.x = this.game.wasmPositions.get(this.wasmID);
+                this.y = this.game.wasmPositionsY.get(this.wasmID);
+            }
+        } else {
+            if (this.life > this.maxLife) {
+                this.markedForDeletion = true;
+            }
 
-        if (this.y < this.toY) {
-            this.y += this.fallSpeed * dt;
+            if (this.y < this.toY) {
+                this.y += this.fallSpeed * dt;
+            }
         }
 
         // Fade out near end
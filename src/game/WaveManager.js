@@ -79,7 +79,8 @@ export class WaveManager {
     }
 
     spawnZombie(type) {
-        const row = Math.floor(Math.random() * this.game.grid.rows);
+        // Lane choice comes from the seeded Go RNG so runs are reproducible
+        const row = window.randomLane ? window.randomLane() : Math.floor(Math.random() * this.game.grid.rows);
         const y = this.game.grid.startY + row * this.game.grid.cellSize + 10;
         this.game.zombies.push(new Zombie(this.game, y, type));
         this.game.zombiesSpawned++;
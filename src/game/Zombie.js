@@ -4,9 +4,11 @@ import { AssetLoader } from './graphics/AssetLoader.js';
 import { WasmLoader } from './graphics/WasmLoader.js';
 
 export class Zombie extends Entity {
-    constructor(game, y, type = 'basic') {
+    // wasmID is passed when Go already spawned the zombie (wave spawns)
+    constructor(game, y, type = 'basic', wasmID) {
         super(game, 1024, y);
         this.type = type;
+        this.wasmID = wasmID;
 
         // Stats based on type or data
         let speed = 0.02;
@@ -16,7 +18,8 @@ export class Zombie extends Entity {
         if (game.gameData && game.gameData.zombies[type]) {
             const stats = game.gameData.zombies[type];
             speed = stats.speed;
-            health = stats.health;
+            // Go keeps armor as its own pool; the JS fallback just adds it on
+            health = stats.health + (stats.armor ? stats.armor.health : 0);
             damage = stats.damage;
         } else {
             // Fallback
@@ -44,6 +47,7 @@ export class Zombie extends Entity {
         this.walkSpeed = 0.005;
 
         this.slowTimer = 0;
+        this.tint = ''; // set from Go status effects, see internal/sim/status.go
         this.currentSpeed = this.speed;
 
         this.initSkeleton();
@@ -53,16 +57,23 @@ export class Zombie extends Entity {
         this.slowTimer = duration;
     }
 
+    // Release the Go-side zombie (and its skeleton) once we're done with it
+    destroy() {
+        if (this.id !== undefined && window.destroyZombie) {
+            window.destroyZombie(this.id);
+            this.id = undefined;
+        }
+    }
+
     initSkeleton() {
         // Check availability of Wasm
         const useWasm = WasmLoader.instance && WasmLoader.instance.isReady && window.createZombie;
 
         if (useWasm) {
-            this.id = window.createZombie(this.type, this.x, this.y);
+            this.id = this.wasmID !== undefined ? this.wasmID : window.createZombie(this.type, this.x, this.y);
             const skelID = window.getZombieSkeletonID(this.id);
 
-            this.skeleton = new WasmSkeleton(0, 0);
-            this.skeleton.id = skelID;
+            this.skeleton = new WasmSkeleton(0, 0, skelID);
 
             // Go `NewZombie` already constructed the skeleton.
             // So we DO NOT need to add bones from JS side.
@@ -125,7 +136,15 @@ export class Zombie extends Entity {
     }
 
     update(deltaTime) {
-        // Wasm Update
+        // Wasm Update: already advanced by Game.stepWasmWorld
+        if (this.id !== undefined && this.game.wasmStep) {
+            const newX = this.game.wasmPositions.get(this.id);
+            if (newX !== undefined) {
+                this.x = newX;
+            }
+            return;
+        }
+
         if (this.id !== undefined && window.updateZombie) {
             const newX = window.updateZombie(this.id, deltaTime);
             if (newX !== -9999.0) {
@@ -257,9 +276,9 @@ export class Zombie extends Entity {
             ctx.restore();
         }
 
-        if (this.slowTimer > 0) {
+        if (this.slowTimer > 0 || this.tint) {
             ctx.save();
-            ctx.fillStyle = 'rgba(100, 149, 237, 0.4)';
+            ctx.fillStyle = this.tint === 'freeze' ? 'rgba(173, 216, 230, 0.6)' : 'rgba(100, 149, 237, 0.4)';
             ctx.fillRect(this.x, this.y, this.width, this.height);
             ctx.restore();
         }
@@ -0,0 +1,59 @@
+// Reads the packed skeleton buffer produced by Go's getRenderBuffer.
+// Layout (all little-endian 4-byte words, see internal/sim/render.go):
+//   header:   version, skeletonCount, floatsPerBone
+//   skeleton: skeletonID, entityID, boneCount, then boneCount * floatsPerBone floats
+//   Bone transforms are already blended to the frame's alpha, so draw them as-is.
+export const RENDER_BUFFER_VERSION = 1;
+
+export class RenderBuffer {
+    static frame = null; // Most recent fetched buffer, shared by WasmSkeleton.draw
+
+    constructor() {
+        this.bytes = new Uint8Array(64 * 1024);
+        this.rebuildViews();
+        this.index = new Map(); // skeletonID -> { offset, boneCount, entityID }
+    }
+
+    rebuildViews() {
+        this.u32 = new Uint32Array(this.bytes.buffer);
+        this.f32 = new Float32Array(this.bytes.buffer);
+    }
+
+    // Pull this frame's data from Go in one call, growing the buffer if needed
+    fetch() {
+        this.index.clear();
+        if (!window.getRenderBuffer) return false;
+
+        let needed = window.getRenderBuffer(this.bytes);
+        if (needed > this.bytes.length) {
+            this.bytes = new Uint8Array(needed * 2);
+            this.rebuildViews();
+            needed = window.getRenderBuffer(this.bytes);
+        }
+
+        if (this.u32[0] !== RENDER_BUFFER_VERSION) {
+            console.warn(`Render buffer version ${this.u32[0]}, expected ${RENDER_BUFFER_VERSION}`);
+            return false;
+        }
+
+        const count = this.u32[1];
+        this.floatsPerBone = this.u32[2];
+
+        let w = 3;
+        for (let i = 0; i < count; i++) {
+            const skeletonID = this.u32[w];
+            const entityID = this.u32[w + 1];
+            const boneCount = this.u32[w + 2];
+            w += 3;
+            this.index.set(skeletonID, { offset: w, boneCount, entityID });
+            w += boneCount * this.floatsPerBone;
+        }
+
+        RenderBuffer.frame = this;
+        return true;
+    }
+
+    get(skeletonID) {
+        return this.index.get(skeletonID);
+    }
+}
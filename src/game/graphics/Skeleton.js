@@ -1,5 +1,6 @@
 import { AssetLoader } from './AssetLoader.js';
 import { WasmLoader } from './WasmLoader.js';
+import { RenderBuffer } from './RenderBuffer.js';
 
 export class Bone {
     constructor(name, image, x, y, pivotX, pivotY) {
@@ -85,15 +86,23 @@ const ImageIDMap = {
     // Add others as needed
 };
 
+const ImageNames = Object.fromEntries(Object.entries(ImageIDMap).map(([name, id]) => [id, name]));
+
 export class WasmSkeleton {
-    constructor(x, y) {
+    // Pass existingID to wrap a skeleton Go already created (entity or
+    // debris skeletons) instead of creating a new one
+    constructor(x, y, existingID) {
         this.x = x;
         this.y = y;
         this.id = -1;
         this.bones = new Map(); // name -> { proxy object for JS manipulation }
         this.renderData = new Float32Array(1024); // Pre-allocate buffer
 
-        this.init(x, y);
+        if (existingID !== undefined) {
+            this.id = existingID;
+        } else {
+            this.init(x, y);
+        }
     }
 
     async init(x, y) {
@@ -157,14 +166,22 @@ export class WasmSkeleton {
     draw(ctx) {
         if (this.id === -1) return;
 
-        // Get data from Wasm
-        // getSkeletonRenderData(id, destArray) -> returns count
-        const count = window.getSkeletonRenderData(this.id, this.renderData);
-
-        // Render
-        // Data layout: [x, y, rot, sx, sy, imgID, px, py] -> 8 floats per bone
-        const stride = 8;
-        const numBones = count / stride;
+        // Prefer the batched per-frame buffer; fall back to a per-skeleton fetch
+        let data = this.renderData;
+        let offset = 0;
+        let numBones;
+        const stride = 8; // [x, y, rot, sx, sy, imgID, px, py]
+
+        const entry = RenderBuffer.frame && RenderBuffer.frame.get(this.id);
+        if (entry) {
+            data = RenderBuffer.frame.f32;
+            offset = entry.offset;
+            numBones = entry.boneCount;
+        } else {
+            // getSkeletonRenderData(id, destArray) -> returns count
+            const count = window.getSkeletonRenderData(this.id, this.renderData);
+            numBones = count / stride;
+        }
 
         ctx.save();
         // The Wasm coordinates are World coordinates (including skeleton X,Y)
@@ -173,15 +190,15 @@ export class WasmSkeleton {
         // Let's assume Wasm returns World coordinates.
 
         for (let i = 0; i < numBones; i++) {
-            const base = i * stride;
-            const x = this.renderData[base + 0];
-            const y = this.renderData[base + 1];
-            const rot = this.renderData[base + 2];
-            const sx = this.renderData[base + 3];
-            const sy = this.renderData[base + 4];
-            const imgID = this.renderData[base + 5];
-            const px = this.renderData[base + 6];
-            const py = this.renderData[base + 7];
+            const base = offset + i * stride;
+            const x = data[base + 0];
+            const y = data[base + 1];
+            const rot = data[base + 2];
+            const sx = data[base + 3];
+            const sy = data[base + 4];
+            const imgID = data[base + 5];
+            const px = data[base + 6];
+            const py = data[base + 7];
 
             ctx.save();
             ctx.translate(x, y);
@@ -201,15 +218,7 @@ export class WasmSkeleton {
 
     getImageByID(id) {
         // Simple switch for now or reverse map
-        let name = "";
-        switch (id) {
-            case 1: name = 'zombie_head'; break;
-            case 2: name = 'zombie_body'; break;
-            case 3: name = 'zombie_arm'; break;
-            case 4: name = 'zombie_leg'; break;
-            case 5: name = 'cone'; break;
-            case 6: name = 'bucket'; break;
-        }
+        let name = ImageNames[id] || "";
         if (name) {
             // Need to import AssetLoader at the top of file or assume global?
             // The file imports AssetLoader at top usually (wait, I need to check top)
@@ -0,0 +1,136 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"syscall/js"
+)
+
+// Every export goes through export() so that a bad call from JS (wrong
+// arity, a string where a number belongs, an unknown ID) comes back as an
+// error instead of panicking and killing the whole Go runtime.
+//
+// Each binding is published twice:
+//
+//	window.pvz.<name>(...) -> {ok: true, value} | {ok: false, error}
+//	window.<name>(...)     -> value, or the binding's legacy fallback on error
+//
+// The plain globals keep the return values existing callers (the game and
+// the modmaker editors) already rely on, and log the error to the console.
+
+type argKind int
+
+const (
+	argNumber argKind = iota
+	argString
+	argBool
+	argObject
+)
+
+func (k argKind) String() string {
+	switch k {
+	case argNumber:
+		return "number"
+	case argString:
+		return "string"
+	case argBool:
+		return "boolean"
+	default:
+		return "object"
+	}
+}
+
+type param struct {
+	name string
+	kind argKind
+}
+
+func num(name string) param  { return param{name, argNumber} }
+func text(name string) param { return param{name, argString} }
+func flag(name string) param { return param{name, argBool} }
+func obj(name string) param  { return param{name, argObject} }
+
+// handler receives arguments that already passed validation.
+type handler func(args []js.Value) (interface{}, error)
+
+var api = js.Global().Get("Object").New()
+
+// export registers fn as window.pvz[name] and window[name]. fallback is what
+// the plain global returns when the call fails.
+func export(name string, params []param, fallback interface{}, fn handler) {
+	structured := func(this js.Value, args []js.Value) interface{} {
+		res := js.Global().Get("Object").New()
+		value, err := call(name, params, fn, args)
+		if err != nil {
+			res.Set("ok", false)
+			res.Set("error", err.Error())
+			return res
+		}
+		res.Set("ok", true)
+		res.Set("value", value)
+		return res
+	}
+	legacy := func(this js.Value, args []js.Value) interface{} {
+		value, err := call(name, params, fn, args)
+		if err != nil {
+			js.Global().Get("console").Call("error", "[wasm] "+err.Error())
+			return fallback
+		}
+		return value
+	}
+
+	api.Set(name, js.FuncOf(structured))
+	js.Global().Set(name, js.FuncOf(legacy))
+}
+
+func call(name string, params []param, fn handler, args []js.Value) (value interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			value = nil
+			err = fmt.Errorf("%s: panic: %v", name, r)
+		}
+	}()
+
+	if err := checkArgs(params, args); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	value, err = fn(args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	// Convert here so unsupported return types are caught by the recover
+	return js.ValueOf(value), nil
+}
+
+func checkArgs(params []param, args []js.Value) error {
+	if len(args) != len(params) {
+		return fmt.Errorf("expected %d arguments, got %d", len(params), len(args))
+	}
+	for i, p := range params {
+		a := args[i]
+		var ok bool
+		switch p.kind {
+		case argNumber:
+			ok = a.Type() == js.TypeNumber && !math.IsNaN(a.Float()) && !math.IsInf(a.Float(), 0)
+		case argString:
+			ok = a.Type() == js.TypeString
+		case argBool:
+			ok = a.Type() == js.TypeBoolean
+		case argObject:
+			ok = a.Type() == js.TypeObject
+		}
+		if !ok {
+			return fmt.Errorf("argument %d (%s) must be a %s, got %s", i+1, p.name, p.kind, describe(a))
+		}
+	}
+	return nil
+}
+
+func describe(v js.Value) string {
+	if v.Type() == js.TypeNumber {
+		return fmt.Sprintf("number %v", v.Float())
+	}
+	return v.Type().String()
+}
@@ -0,0 +1,66 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"math"
+	"strings"
+	"syscall/js"
+	"testing"
+)
+
+func TestCheckArgs(t *testing.T) {
+	params := []param{num("id"), text("kind"), flag("loop"), obj("dest")}
+	dest := js.Global().Get("Object").New()
+
+	tests := []struct {
+		name string
+		args []any
+		want string // error substring, "" for none
+	}{
+		{"valid", []any{3, "basic", true, dest}, ""},
+		{"too few", []any{3, "basic", true}, "expected 4 arguments, got 3"},
+		{"too many", []any{3, "basic", true, dest, 1}, "expected 4 arguments, got 5"},
+		{"string for number", []any{"3", "basic", true, dest}, "argument 1 (id) must be a number, got string"},
+		{"NaN", []any{math.NaN(), "basic", true, dest}, "argument 1 (id) must be a number, got number NaN"},
+		{"infinity", []any{math.Inf(1), "basic", true, dest}, "must be a number, got number +Inf"},
+		{"number for string", []any{3, 4, true, dest}, "argument 2 (kind) must be a string, got number 4"},
+		{"undefined bool", []any{3, "basic", js.Undefined(), dest}, "argument 3 (loop) must be a boolean, got undefined"},
+		{"null object", []any{3, "basic", false, js.Null()}, "argument 4 (dest)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]js.Value, len(tt.args))
+			for i, a := range tt.args {
+				args[i] = js.ValueOf(a)
+			}
+			err := checkArgs(params, args)
+			switch {
+			case tt.want == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+				t.Fatalf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallRejectsBeforeTheHandlerRuns(t *testing.T) {
+	ran := false
+	fn := func([]js.Value) (interface{}, error) {
+		ran = true
+		return nil, nil
+	}
+	_, err := call("destroyZombie", []param{num("id")}, fn, []js.Value{js.ValueOf("7")})
+	if err == nil || !strings.HasPrefix(err.Error(), "destroyZombie: ") {
+		t.Fatalf("error = %v, want one naming the binding", err)
+	}
+	if ran {
+		t.Error("handler ran with a bad argument")
+	}
+
+	_, err = call("boom", nil, func([]js.Value) (interface{}, error) { panic("bad index") }, nil)
+	if err == nil || !strings.Contains(err.Error(), "panic: bad index") {
+		t.Errorf("error = %v, want the panic reported", err)
+	}
+}
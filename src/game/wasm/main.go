@@ -1,100 +1,143 @@
+//go:build js && wasm
+
 package main
 
 import (
+	"encoding/binary"
 	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
 	"syscall/js"
-)
-
-// Global store of skeletons
-// (Moved to top or keep one instance. I will keep the one lower down if it exists, or here)
-// Actually, let's keep references here and ensure no duplicates lower.
-// But simpler: Just use this space for Entities.
-
-// Entities
-var zombies = make(map[int]*Zombie)
-var plants = make(map[int]*Plant)
-var daves = make(map[int]*Dave)
-var nextEntityID = 1
 
-// Events buffer
-var eventBuffer []interface{}
-
-// Global store of skeletons
-var skeletons = make(map[int]*Skeleton)
-var nextSkelID = 1
+	"pvz/internal/sim"
+)
 
-// Global store of animations
-var animations = make(map[int]*Animation)
-var nextAnimID = 1
+// All game state lives in the simulation package; this file only converts
+// between js.Value arguments and sim calls.
+var world = sim.NewWorld()
 
 func main() {
 	c := make(chan struct{}, 0)
 
-	fmt := js.Global().Get("console")
-	fmt.Call("log", "Wasm Animation System Initialized")
+	console := js.Global().Get("console")
+	console.Call("log", "Wasm Animation System Initialized")
 
 	// Skel Exports
-	js.Global().Set("createSkeleton", js.FuncOf(createSkeleton))
-	js.Global().Set("addBone", js.FuncOf(addBone))
-	js.Global().Set("updateSkeleton", js.FuncOf(updateSkeleton))
-	js.Global().Set("getSkeletonRenderData", js.FuncOf(getSkeletonRenderData))
-	js.Global().Set("setBoneTransform", js.FuncOf(setBoneTransform))
+	export("createSkeleton", []param{num("x"), num("y")}, -1, createSkeleton)
+	export("addBone", []param{num("skelID"), text("parentName"), text("name"), num("imgID"),
+		num("x"), num("y"), num("rot"), num("sx"), num("sy"), num("px"), num("py")}, false, addBone)
+	export("updateSkeleton", []param{num("skelID"), num("dt")}, nil, updateSkeleton)
+	export("getSkeletonRenderData", []param{num("skelID"), obj("dest")}, 0, getSkeletonRenderData)
+	export("setBoneTransform", []param{num("skelID"), text("boneName"),
+		num("x"), num("y"), num("rot"), num("sx"), num("sy")}, false, setBoneTransform)
+	export("getRenderBuffer", []param{obj("dest")}, 0, getRenderBuffer)
+	export("destroySkeleton", []param{num("skelID")}, false, destroySkeleton)
 
 	// Animation Exports
-	js.Global().Set("createAnimation", js.FuncOf(createAnimation))
-	js.Global().Set("addKeyframe", js.FuncOf(addKeyframe))
-	js.Global().Set("applyAnimation", js.FuncOf(applyAnimation))
-	js.Global().Set("getAnimationJSON", js.FuncOf(getAnimationJSON))
+	export("createAnimation", []param{text("name"), num("duration")}, -1, createAnimation)
+	export("addKeyframe", []param{num("animID"), num("time"), text("boneName"),
+		num("x"), num("y"), num("rot"), num("sx"), num("sy")}, false, addKeyframe)
+	export("applyAnimation", []param{num("skelID"), num("animID"), num("time"), flag("loop")}, false, applyAnimation)
+	export("getAnimationJSON", []param{num("animID")}, "", getAnimationJSON)
+	export("destroyAnimation", []param{num("animID")}, false, destroyAnimation)
 
 	// Grid Exports
-	js.Global().Set("initGrid", js.FuncOf(initGridWrapper))
-	js.Global().Set("checkGridHover", js.FuncOf(checkGridHover))
-	js.Global().Set("getGridHoverState", js.FuncOf(getGridHoverState))
+	export("initGrid", []param{num("rows"), num("cols"), num("cellSize"), num("startX"), num("startY")}, nil, initGridWrapper)
+	export("checkGridHover", []param{num("x"), num("y")}, nil, checkGridHover)
+	export("getGridHoverState", nil, nil, getGridHoverState)
 
 	// Entity Exports
-	js.Global().Set("createZombie", js.FuncOf(createZombie))
-	js.Global().Set("updateZombie", js.FuncOf(updateZombie))
-	js.Global().Set("getZombieSkeletonID", js.FuncOf(getZombieSkeletonID))
-
-	js.Global().Set("createPlant", js.FuncOf(createPlant))
-	js.Global().Set("updatePlant", js.FuncOf(updatePlant))
+	export("createZombie", []param{text("type"), num("x"), num("y")}, -1, createZombie)
+	export("updateZombie", []param{num("id"), num("dt")}, -9999.0, updateZombie)
+	export("getZombieSkeletonID", []param{num("id")}, -1, getZombieSkeletonID)
+	export("destroyZombie", []param{num("id")}, false, destroyZombie)
+
+	export("createPlant", []param{text("type"), num("x"), num("y")}, -1, createPlant)
+	export("updatePlant", []param{num("id"), num("dt")}, nil, updatePlant)
+	export("destroyPlant", []param{num("id")}, false, destroyPlant)
+
+	export("createDave", []param{num("x"), num("y")}, nil, createDave)
+	export("updateDave", []param{num("id"), num("dt")}, nil, updateDave)
+	export("daveAppear", []param{num("id")}, nil, daveAppear)
+	export("destroyDave", []param{num("id")}, false, destroyDave)
+
+	export("getEntityCounts", nil, nil, getEntityCounts)
+
+	export("pollEvents", nil, nil, pollEvents)
+	export("getEventProtocolVersion", nil, 0, getEventProtocolVersion)
+
+	// World Exports
+	export("stepWorld", []param{num("dt")}, nil, stepWorld)
+	export("resetWorld", nil, nil, resetWorld)
+	export("setSeed", []param{num("seed")}, nil, setSeed)
+	export("getSeed", nil, 0, getSeed)
+	export("randomLane", nil, 0, randomLane)
+	export("randomSkySun", nil, nil, randomSkySun)
+	export("saveSnapshot", nil, "", saveSnapshot)
+	export("restoreSnapshot", []param{text("snapshot")}, false, restoreSnapshot)
+	export("getEntities", nil, nil, getEntities)
+
+	// Economy Exports
+	export("setSun", []param{num("amount")}, nil, setSun)
+	export("getSun", nil, 0, getSun)
+	export("setSkySunInterval", []param{num("ms")}, nil, setSkySunInterval)
+	export("getSeedStatus", []param{text("kind")}, nil, getSeedStatus)
+
+	// Level Exports
+	export("startLevel", []param{text("waves")}, false, startLevel)
+	export("startEndless", []param{text("config")}, false, startEndless)
+	export("getWaveProgress", nil, 0, getWaveProgress)
+	export("getWaveState", nil, nil, getWaveState)
+
+	// Data Exports
+	export("loadRegistry", []param{text("plants"), text("zombies")}, false, loadRegistry)
+
+	// Input & Replay Exports
+	export("submitCommand", []param{obj("command")}, false, submitCommand)
+	export("startRecording", nil, nil, startRecording)
+	export("stopRecording", nil, "", stopRecording)
+	export("playReplay", []param{text("replay")}, false, playReplay)
+	export("isReplaying", nil, false, isReplaying)
+
+	js.Global().Set("pvz", api)
 
-	js.Global().Set("createDave", js.FuncOf(createDave))
-	js.Global().Set("updateDave", js.FuncOf(updateDave))
+	<-c
+}
 
-	js.Global().Set("pollEvents", js.FuncOf(pollEvents))
+func skeletonArg(v js.Value) (*sim.Skeleton, error) {
+	skel, ok := world.Skeletons[v.Int()]
+	if !ok {
+		return nil, fmt.Errorf("unknown skeleton %d", v.Int())
+	}
+	return skel, nil
+}
 
-	<-c
+func animationArg(v js.Value) (*sim.Animation, error) {
+	anim, ok := world.Animations[v.Int()]
+	if !ok {
+		return nil, fmt.Errorf("unknown animation %d", v.Int())
+	}
+	return anim, nil
 }
 
-func createSkeleton(this js.Value, args []js.Value) interface{} {
+func createSkeleton(args []js.Value) (interface{}, error) {
 	x := float32(args[0].Float())
 	y := float32(args[1].Float())
-
-	id := nextSkelID
-	nextSkelID++
-
-	skel := NewSkeleton(x, y)
-	skeletons[id] = skel
-
-	return id
+	return world.CreateSkeleton(x, y), nil
 }
 
 // addBone(skelID, parentName, name, imgID, x, y, rot, sx, sy, px, py)
-func addBone(this js.Value, args []js.Value) interface{} {
-	skelID := args[0].Int()
-	parentName := args[1].String()
-	name := args[2].String()
-	imgID := args[3].Int()
-
-	skel, ok := skeletons[skelID]
-	if !ok {
-		return false
+func addBone(args []js.Value) (interface{}, error) {
+	skel, err := skeletonArg(args[0])
+	if err != nil {
+		return nil, err
 	}
 
-	bone := &Bone{
-		Name:     name,
-		ImageID:  imgID,
+	bone := &sim.Bone{
+		Name:     args[2].String(),
+		ImageID:  args[3].Int(),
 		LocalX:   float32(args[4].Float()),
 		LocalY:   float32(args[5].Float()),
 		Rotation: float32(args[6].Float()),
@@ -103,102 +146,102 @@ func addBone(this js.Value, args []js.Value) interface{} {
 		PivotX:   float32(args[9].Float()),
 		PivotY:   float32(args[10].Float()),
 	}
-
-	if parentName == "" {
-		skel.Root = bone
-	} else {
-		if parent, ok := skel.Bones[parentName]; ok {
-			bone.Parent = parent
-			parent.Children = append(parent.Children, bone)
-		} else {
-			// Parent not found, maybe simply fail or add to root if explicit?
-			// For this tool, let's strict fail
-			return false
-		}
+	if !skel.AddBone(args[1].String(), bone) {
+		return nil, fmt.Errorf("parent bone %q not found", args[1].String())
 	}
-	skel.Bones[name] = bone
-	return true
+	return true, nil
 }
 
-func updateSkeleton(this js.Value, args []js.Value) interface{} {
-	id := args[0].Int()
-	dt := float32(args[1].Float())
-
-	if skel, ok := skeletons[id]; ok {
-		skel.Update(dt)
+func updateSkeleton(args []js.Value) (interface{}, error) {
+	skel, err := skeletonArg(args[0])
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	skel.Update(float32(args[1].Float()))
+	return nil, nil
 }
 
-func getSkeletonRenderData(this js.Value, args []js.Value) interface{} {
-	id := args[0].Int()
+func getSkeletonRenderData(args []js.Value) (interface{}, error) {
+	skel, err := skeletonArg(args[0])
+	if err != nil {
+		return nil, err
+	}
 	destArray := args[1] // Expecting a Float32Array
 
-	if skel, ok := skeletons[id]; ok {
-		data := skel.GetRenderData()
+	data := skel.GetRenderData()
+	if len(data) > destArray.Length() {
+		return nil, fmt.Errorf("destination holds %d floats, need %d", destArray.Length(), len(data))
+	}
 
-		// Fill JS array
-		for i, v := range data {
-			destArray.SetIndex(i, float64(v))
-		}
-		return len(data)
+	// Fill JS array
+	for i, v := range data {
+		destArray.SetIndex(i, float64(v))
 	}
-	return 0
+	return len(data), nil
 }
 
-func setBoneTransform(this js.Value, args []js.Value) interface{} {
-	skelID := args[0].Int()
-	boneName := args[1].String()
-
-	skel, ok := skeletons[skelID]
-	if !ok {
-		return false
+// Reused across frames; see sim.AppendRenderBuffer for the layout
+var renderScratch []byte
+
+// getRenderBuffer(destUint8Array) packs every visible skeleton into dest with
+// a single copy. Returns the number of bytes the frame needs; if that is
+// larger than dest.length nothing is copied and the caller should grow dest
+// and call again.
+func getRenderBuffer(args []js.Value) (interface{}, error) {
+	dest := args[0]
+	if !dest.InstanceOf(js.Global().Get("Uint8Array")) {
+		return nil, fmt.Errorf("argument 1 (dest) must be a Uint8Array")
 	}
-	bone, ok := skel.Bones[boneName]
-	if !ok {
-		return false
+
+	renderScratch = world.AppendRenderBuffer(renderScratch[:0])
+	if len(renderScratch) <= dest.Length() {
+		js.CopyBytesToJS(dest, renderScratch)
 	}
+	return len(renderScratch), nil
+}
 
-	bone.LocalX = float32(args[2].Float())
-	bone.LocalY = float32(args[3].Float())
-	bone.Rotation = float32(args[4].Float())
-	bone.ScaleX = float32(args[5].Float())
-	bone.ScaleY = float32(args[6].Float())
+func setBoneTransform(args []js.Value) (interface{}, error) {
+	skel, err := skeletonArg(args[0])
+	if err != nil {
+		return nil, err
+	}
 
-	// Force update world transforms immediately for editor responsiveness
-	skel.Update(0)
+	ok := skel.SetBoneTransform(args[1].String(),
+		float32(args[2].Float()),
+		float32(args[3].Float()),
+		float32(args[4].Float()),
+		float32(args[5].Float()),
+		float32(args[6].Float()),
+	)
+	if !ok {
+		return nil, fmt.Errorf("unknown bone %q", args[1].String())
+	}
+	return true, nil
+}
 
-	return true
+func destroySkeleton(args []js.Value) (interface{}, error) {
+	return world.DestroySkeleton(args[0].Int()), nil
 }
 
 // --- Animation Bindings ---
 
-func createAnimation(this js.Value, args []js.Value) interface{} {
+func createAnimation(args []js.Value) (interface{}, error) {
 	name := args[0].String()
 	duration := float32(args[1].Float())
-
-	id := nextAnimID
-	nextAnimID++
-
-	anim := &Animation{
-		Name:      name,
-		Duration:  duration,
-		Keyframes: []Keyframe{},
+	if duration < 0 {
+		return nil, fmt.Errorf("duration must not be negative, got %v", duration)
 	}
-	animations[id] = anim
-
-	return id
+	return world.CreateAnimation(name, duration), nil
 }
 
 // addKeyframe(animID, time, boneName, x, y, rot, sx, sy)
-func addKeyframe(this js.Value, args []js.Value) interface{} {
-	animID := args[0].Int()
-	anim, ok := animations[animID]
-	if !ok {
-		return false
+func addKeyframe(args []js.Value) (interface{}, error) {
+	anim, err := animationArg(args[0])
+	if err != nil {
+		return nil, err
 	}
 
-	kf := Keyframe{
+	anim.AddKeyframe(sim.Keyframe{
 		Time:     float32(args[1].Float()),
 		BoneName: args[2].String(),
 		X:        float32(args[3].Float()),
@@ -206,215 +249,584 @@ func addKeyframe(this js.Value, args []js.Value) interface{} {
 		Rotation: float32(args[5].Float()),
 		ScaleX:   float32(args[6].Float()),
 		ScaleY:   float32(args[7].Float()),
-	}
-
-	// Insert sorted or append? For now append, frontend should be careful or we sort.
-	// Let's just append.
-	anim.Keyframes = append(anim.Keyframes, kf)
-	return true
+	})
+	return true, nil
 }
 
-func applyAnimation(this js.Value, args []js.Value) interface{} {
-	skelID := args[0].Int()
-	animID := args[1].Int()
-	time := float32(args[2].Float())
-	loop := args[3].Bool()
-
-	skel, okS := skeletons[skelID]
-	anim, okA := animations[animID]
-
-	if okS && okA {
-		anim.ApplyAt(skel, time, loop)
-		skel.Update(0) // Recalculate world transforms
-		return true
+func applyAnimation(args []js.Value) (interface{}, error) {
+	skel, err := skeletonArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	anim, err := animationArg(args[1])
+	if err != nil {
+		return nil, err
 	}
-	return false
+
+	anim.ApplyAt(skel, float32(args[2].Float()), args[3].Bool())
+	skel.Update(0) // Recalculate world transforms
+	return true, nil
 }
 
-func getAnimationJSON(this js.Value, args []js.Value) interface{} {
-	animID := args[0].Int()
-	anim, ok := animations[animID]
-	if !ok {
-		return ""
+func getAnimationJSON(args []js.Value) (interface{}, error) {
+	anim, err := animationArg(args[0])
+	if err != nil {
+		return nil, err
 	}
 
 	bytes, err := json.MarshalIndent(anim, "", "  ")
 	if err != nil {
-		return ""
+		return nil, err
 	}
-	return string(bytes)
+	return string(bytes), nil
+}
+
+func destroyAnimation(args []js.Value) (interface{}, error) {
+	return world.DestroyAnimation(args[0].Int()), nil
 }
 
 // --- Grid Bindings ---
 
-func initGridWrapper(this js.Value, args []js.Value) interface{} {
+func initGridWrapper(args []js.Value) (interface{}, error) {
 	rows := args[0].Int()
 	cols := args[1].Int()
 	cellSize := args[2].Float()
+	if rows <= 0 || cols <= 0 || cellSize <= 0 {
+		return nil, fmt.Errorf("rows, cols and cellSize must be positive, got %d, %d, %v", rows, cols, cellSize)
+	}
 	startX := args[3].Float()
 	startY := args[4].Float()
-	InitGrid(rows, cols, cellSize, startX, startY)
-	return nil
+	world.Grid.Init(rows, cols, cellSize, startX, startY)
+	return nil, nil
 }
 
-func checkGridHover(this js.Value, args []js.Value) interface{} {
+func checkGridHover(args []js.Value) (interface{}, error) {
 	x := args[0].Float()
 	y := args[1].Float()
-	CheckHover(x, y)
-	return nil
+	world.Grid.CheckHover(x, y)
+	return nil, nil
 }
 
-func getGridHoverState(this js.Value, args []js.Value) interface{} {
+func getGridHoverState(args []js.Value) (interface{}, error) {
 	// Returns [row, col]
 	res := js.Global().Get("Array").New(2)
-	res.SetIndex(0, float64(globalGrid.HoverRow))
-	res.SetIndex(1, float64(globalGrid.HoverCol))
-	return res
+	res.SetIndex(0, float64(world.Grid.HoverRow))
+	res.SetIndex(1, float64(world.Grid.HoverCol))
+	return res, nil
 }
 
 // --- Event System ---
 
-func emitEvent(typ string, id int, payload interface{}) {
-	// Simple map or struct
-	evt := map[string]interface{}{
-		"type":    typ,
-		"id":      id,
-		"payload": payload,
-	}
-	eventBuffer = append(eventBuffer, evt)
+func pollEvents(args []js.Value) (interface{}, error) {
+	return eventsToJS(world.PollEvents()), nil
 }
 
-func pollEvents(this js.Value, args []js.Value) interface{} {
-	if len(eventBuffer) == 0 {
-		return js.Global().Get("Array").New(0)
-	}
-
-	// Marshaling []interface{} to JS is tricky with syscall/js directly if complex.
-	// But `js.ValueOf` handles []interface{} recursiely often?
-	// Actually `js.ValueOf` doesn't handle slices automatically in all versions.
-	// Let's return JSON string for reliability or build JS array manually.
-	// Building manually is safer.
-
-	res := js.Global().Get("Array").New(len(eventBuffer))
-	for i, evt := range eventBuffer {
-		// evt is map
-		m, ok := evt.(map[string]interface{})
-		if !ok {
-			continue
-		}
+// eventsToJS converts to the wire format documented on sim.Event. Every
+// field is always set so JS sees the same object shape for every type.
+func eventsToJS(events []sim.Event) js.Value {
+	res := js.Global().Get("Array").New(len(events))
+	for i, evt := range events {
 		jsEvt := js.Global().Get("Object").New()
-		jsEvt.Set("type", m["type"])
-		jsEvt.Set("id", m["id"])
-		jsEvt.Set("payload", m["payload"]) // Note: Payload must be simple type or js.Value
+		jsEvt.Set("type", string(evt.Type))
+		jsEvt.Set("id", evt.ID)
+		jsEvt.Set("row", evt.Row)
+		jsEvt.Set("col", evt.Col)
+		jsEvt.Set("x", evt.X)
+		jsEvt.Set("y", evt.Y)
+		jsEvt.Set("amount", evt.Amount)
+		jsEvt.Set("kind", evt.Kind)
+		jsEvt.Set("target", evt.Target)
 		res.SetIndex(i, jsEvt)
 	}
-
-	// Optimization: Reuse buffer capacity
-	eventBuffer = eventBuffer[:0]
 	return res
 }
 
+func getEventProtocolVersion(args []js.Value) (interface{}, error) {
+	return sim.EventProtocolVersion, nil
+}
+
 // --- Entity Bindings ---
 
-func createZombie(this js.Value, args []js.Value) interface{} {
+func zombieArg(v js.Value) (*sim.Zombie, error) {
+	z, ok := world.Zombies[v.Int()]
+	if !ok {
+		return nil, fmt.Errorf("unknown zombie %d", v.Int())
+	}
+	return z, nil
+}
+
+func createZombie(args []js.Value) (interface{}, error) {
 	// args: type, x, y
 	typ := args[0].String()
 	x := float32(args[1].Float())
 	y := float32(args[2].Float())
-
-	id := nextEntityID
-	nextEntityID++
-
-	z := NewZombie(id, typ, x, y)
-	zombies[id] = z
-
-	// Register Skeleton in global map so JS can find it for bone updates during init
-	if z.Skeleton != nil {
-		skelID := nextSkelID
-		nextSkelID++
-		skeletons[skelID] = z.Skeleton
-
-		// Store mapped ID in zombie for O(1) retrieval
-		z.SkeletonID = skelID
+	z := world.CreateZombie(typ, x, y)
+	if z == nil {
+		return nil, fmt.Errorf("unknown zombie type %q", typ)
 	}
-
-	return id
+	return z.ID, nil
 }
 
-func getZombieSkeletonID(this js.Value, args []js.Value) interface{} {
-	zID := args[0].Int()
-	z, ok := zombies[zID]
-	if !ok || z.Skeleton == nil {
-		return -1
+func getZombieSkeletonID(args []js.Value) (interface{}, error) {
+	z, err := zombieArg(args[0])
+	if err != nil {
+		return nil, err
 	}
-	// Optimized O(1) lookup
-	return z.SkeletonID
+	if z.Skeleton == nil {
+		return -1, nil
+	}
+	return z.SkeletonID, nil
 }
 
-func updateZombie(this js.Value, args []js.Value) interface{} {
-	id := args[0].Int()
-	dt := float32(args[1].Float())
-
-	if z, ok := zombies[id]; ok {
-		z.Update(dt)
-		return float64(z.X)
+func updateZombie(args []js.Value) (interface{}, error) {
+	z, err := zombieArg(args[0])
+	if err != nil {
+		return nil, err
 	}
-	return -9999.0 // Error code
+	z.Update(float32(args[1].Float()))
+	return float64(z.X), nil
+}
+
+// destroyZombie also unregisters the zombie's skeleton
+func destroyZombie(args []js.Value) (interface{}, error) {
+	return world.DestroyZombie(args[0].Int()), nil
 }
 
 // --- Plant Bindings ---
 
-func createPlant(this js.Value, args []js.Value) interface{} {
+func createPlant(args []js.Value) (interface{}, error) {
 	typ := args[0].String()
 	x := float32(args[1].Float())
 	y := float32(args[2].Float())
-
-	id := nextEntityID
-	nextEntityID++
-
-	p := NewPlant(id, typ, x, y)
-	plants[id] = p
-	return id
+	p := world.CreatePlant(typ, x, y)
+	if p == nil {
+		return nil, fmt.Errorf("unknown plant type %q", typ)
+	}
+	return p.ID, nil
 }
 
-func updatePlant(this js.Value, args []js.Value) interface{} {
-	id := args[0].Int()
-	dt := float32(args[1].Float())
-	if p, ok := plants[id]; ok {
-		p.Update(dt)
+func updatePlant(args []js.Value) (interface{}, error) {
+	p, ok := world.Plants[args[0].Int()]
+	if !ok {
+		return nil, fmt.Errorf("unknown plant %d", args[0].Int())
 	}
-	return nil
+	p.Update(world, float32(args[1].Float()))
+	return nil, nil
+}
+
+func destroyPlant(args []js.Value) (interface{}, error) {
+	return world.DestroyPlant(args[0].Int()), nil
 }
 
 // --- Dave Bindings ---
 
-func createDave(this js.Value, args []js.Value) interface{} {
+func createDave(args []js.Value) (interface{}, error) {
 	x := float32(args[0].Float())
 	y := float32(args[1].Float())
 
-	id := nextEntityID
-	nextEntityID++
+	d := world.CreateDave(x, y)
 
-	d := NewDave(id, x, y)
-	daves[id] = d
+	res := js.Global().Get("Object").New()
+	res.Set("id", d.ID)
+	res.Set("skelId", d.SkeletonID)
+	return res, nil
+}
+
+func updateDave(args []js.Value) (interface{}, error) {
+	d, ok := world.Daves[args[0].Int()]
+	if !ok {
+		return nil, fmt.Errorf("unknown dave %d", args[0].Int())
+	}
+	d.Update(float32(args[1].Float()))
+	return nil, nil
+}
+
+// daveAppear(id) shows Dave and starts his slide in
+func daveAppear(args []js.Value) (interface{}, error) {
+	d, ok := world.Daves[args[0].Int()]
+	if !ok {
+		return nil, fmt.Errorf("unknown dave %d", args[0].Int())
+	}
+	d.Appear()
+	return nil, nil
+}
+
+func destroyDave(args []js.Value) (interface{}, error) {
+	return world.DestroyDave(args[0].Int()), nil
+}
+
+// --- Diagnostics ---
+
+// getEntityCounts returns {zombies, plants, daves, projectiles, suns, skeletons, debris, animations}
+func getEntityCounts(args []js.Value) (interface{}, error) {
+	c := world.Counts()
+	return map[string]interface{}{
+		"zombies":     c.Zombies,
+		"plants":      c.Plants,
+		"daves":       c.Daves,
+		"projectiles": c.Projectiles,
+		"suns":        c.Suns,
+		"skeletons":   c.Skeletons,
+		"debris":      c.Debris,
+		"animations":  c.Animations,
+	}, nil
+}
 
-	// Register Skeleton
-	skelID := nextSkelID
-	nextSkelID++
-	skeletons[skelID] = d.Skeleton
+// --- World Bindings ---
+
+// Reused across frames: stepWorld packs positions into stepScratch and
+// copies them into stepBytes, which JS reads through stepFloats. Both only
+// grow when a frame needs more room than any before it.
+var (
+	stepScratch []byte
+	stepBytes   js.Value // Uint8Array
+	stepFloats  js.Value // Float32Array over stepBytes' buffer
+)
+
+// stepWorld(frameDt) feeds real frame time into the fixed-timestep clock and
+// returns {positions, count, deaths: [id...], events: [...], ticks, tick,
+// alpha}. positions is a Float32Array of [id, x, y, prevX, prevY, ...]
+// shared between calls; only its first count entries belong to this step.
+// prevX/prevY are the position one tick earlier, so draw at
+// prev + (cur - prev) * alpha. Events are drained, so pollEvents is not
+// needed when using stepWorld.
+func stepWorld(args []js.Value) (interface{}, error) {
+	dt := float32(args[0].Float())
+	if dt < 0 {
+		return nil, fmt.Errorf("dt must not be negative, got %v", dt)
+	}
+	step := world.Advance(dt)
+
+	n := len(step.Positions) * 5 * 4
+	if cap(stepScratch) < n {
+		stepScratch = make([]byte, n)
+	}
+	buf := stepScratch[:n]
+	for i, p := range step.Positions {
+		prev := step.Previous[i]
+		off := i * 20
+		binary.LittleEndian.PutUint32(buf[off:], math.Float32bits(float32(p.ID)))
+		binary.LittleEndian.PutUint32(buf[off+4:], math.Float32bits(p.X))
+		binary.LittleEndian.PutUint32(buf[off+8:], math.Float32bits(p.Y))
+		binary.LittleEndian.PutUint32(buf[off+12:], math.Float32bits(prev.X))
+		binary.LittleEndian.PutUint32(buf[off+16:], math.Float32bits(prev.Y))
+	}
+	if stepBytes.IsUndefined() || stepBytes.Length() < n {
+		stepBytes = js.Global().Get("Uint8Array").New(max(2*n, 1024))
+		stepFloats = js.Global().Get("Float32Array").New(stepBytes.Get("buffer"))
+	}
+	js.CopyBytesToJS(stepBytes, buf)
+
+	deaths := js.Global().Get("Array").New(len(step.Deaths))
+	for i, id := range step.Deaths {
+		deaths.SetIndex(i, id)
+	}
 
-	// Return {id, skelID} object?
 	res := js.Global().Get("Object").New()
-	res.Set("id", id)
-	res.Set("skelId", skelID)
-	return res
+	res.Set("positions", stepFloats)
+	res.Set("count", len(step.Positions))
+	res.Set("deaths", deaths)
+	res.Set("events", eventsToJS(step.Events))
+	res.Set("ticks", step.Ticks)
+	res.Set("tick", float64(world.Clock.Tick))
+	res.Set("alpha", step.Alpha)
+	return res, nil
+}
+
+// resetWorld() clears the previous run (everything but Crazy Dave, see
+// World.Reset) so a restart doesn't inherit suns, shots or recharge timers.
+func resetWorld(args []js.Value) (interface{}, error) {
+	world.Reset()
+	return nil, nil
 }
 
-func updateDave(this js.Value, args []js.Value) interface{} {
-	id := args[0].Int()
-	dt := float32(args[1].Float())
-	if d, ok := daves[id]; ok {
-		d.Update(dt)
+// setSeed(seed) reseeds the simulation RNG. Seeds must be non-negative
+// integers that fit in a JS number exactly (< 2^53).
+func setSeed(args []js.Value) (interface{}, error) {
+	seed := args[0].Float()
+	if seed < 0 || seed != math.Trunc(seed) || seed >= 1<<53 {
+		return nil, fmt.Errorf("seed must be a non-negative integer below 2^53, got %v", seed)
 	}
-	return nil
+	world.SetSeed(uint64(seed))
+	return nil, nil
+}
+
+func getSeed(args []js.Value) (interface{}, error) {
+	return float64(world.Seed), nil
+}
+
+func randomLane(args []js.Value) (interface{}, error) {
+	return world.RandomLane(), nil
+}
+
+// randomSkySun returns {x, y}: where a sky sun should fall and land
+func randomSkySun(args []js.Value) (interface{}, error) {
+	x, y := world.RandomSkySun()
+	return map[string]interface{}{"x": x, "y": y}, nil
+}
+
+// --- Snapshots ---
+
+// saveSnapshot() returns the whole simulation as a versioned JSON string,
+// for a checkpoint or for attaching to a bug report.
+func saveSnapshot(args []js.Value) (interface{}, error) {
+	data, err := world.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// restoreSnapshot(json) replaces the world with a saveSnapshot() string.
+// Entities keep their snapshot IDs; JS drops its own objects and rebuilds
+// them from getEntities(). On error the world is left as it was.
+func restoreSnapshot(args []js.Value) (interface{}, error) {
+	if err := world.Restore([]byte(args[0].String())); err != nil {
+		return nil, err
+	}
+	return true, nil
+}
+
+// getEntities() lists everything JS draws, each in ID order, so the
+// renderer can rebuild its objects after restoreSnapshot:
+// {sun, plants, zombies, suns, projectiles, debris}. Zombie health counts
+// armor too, the same pool Zombie.js keeps.
+func getEntities(args []js.Value) (interface{}, error) {
+	plants := []interface{}{}
+	for _, id := range slices.Sorted(maps.Keys(world.Plants)) {
+		p := world.Plants[id]
+		plants = append(plants, map[string]interface{}{
+			"id": id, "type": p.Type, "row": p.Row, "col": p.Col, "x": p.X,
+			"health": p.Health, "maxHealth": p.MaxHealth,
+			"damageTier": p.DamageTier, "skin": p.Skin,
+			"animState": p.AnimState, "stateTimer": p.StateTimer, "targetX": p.TargetX,
+			"isArmed": p.IsArmed,
+		})
+	}
+
+	zombies := []interface{}{}
+	for _, id := range slices.Sorted(maps.Keys(world.Zombies)) {
+		z := world.Zombies[id]
+		health, maxHealth := z.Health, z.MaxHealth
+		if z.Armor != nil {
+			health += z.Armor.Health
+			maxHealth += z.Armor.MaxHealth
+		}
+		zombies = append(zombies, map[string]interface{}{
+			"id": id, "type": z.Type, "x": z.X, "y": z.Y,
+			"health": health, "maxHealth": maxHealth,
+			"isEating": z.IsEating, "isDying": z.Dying, "tint": string(z.Tint),
+		})
+	}
+
+	suns := []interface{}{}
+	for _, id := range slices.Sorted(maps.Keys(world.Suns)) {
+		sun := world.Suns[id]
+		suns = append(suns, map[string]interface{}{
+			"id": id, "x": sun.X, "y": sun.Y, "targetY": sun.TargetY, "value": sun.Value,
+		})
+	}
+
+	projectiles := []interface{}{}
+	for _, id := range slices.Sorted(maps.Keys(world.Projectiles)) {
+		pr := world.Projectiles[id]
+		projectiles = append(projectiles, map[string]interface{}{
+			"id": id, "kind": pr.Kind, "x": pr.X, "y": pr.Y,
+		})
+	}
+
+	debris := []interface{}{}
+	for _, id := range slices.Sorted(maps.Keys(world.Debris)) {
+		if s, ok := world.Skeletons[id]; ok {
+			debris = append(debris, map[string]interface{}{"id": id, "x": s.X, "y": s.Y})
+		}
+	}
+
+	return map[string]interface{}{
+		"sun":         world.Economy.Sun,
+		"plants":      plants,
+		"zombies":     zombies,
+		"suns":        suns,
+		"projectiles": projectiles,
+		"debris":      debris,
+	}, nil
+}
+
+// --- Economy ---
+
+func setSun(args []js.Value) (interface{}, error) {
+	world.SetSun(args[0].Int())
+	return nil, nil
+}
+
+func getSun(args []js.Value) (interface{}, error) {
+	return world.Economy.Sun, nil
+}
+
+// setSkySunInterval(ms) sets how often sky sun falls; 0 turns it off
+func setSkySunInterval(args []js.Value) (interface{}, error) {
+	world.SetSkySunInterval(float32(args[0].Float()))
+	return nil, nil
+}
+
+// getSeedStatus(kind) returns {cost, cooldown, recharge, affordable, ready}
+// so the seed bar can grey out packets before the player clicks.
+func getSeedStatus(args []js.Value) (interface{}, error) {
+	st := world.SeedStatus(args[0].String())
+	return map[string]interface{}{
+		"cost":       st.Cost,
+		"cooldown":   st.Cooldown,
+		"recharge":   st.Recharge,
+		"affordable": st.Affordable,
+		"ready":      st.Ready,
+	}, nil
+}
+
+// --- Levels ---
+
+// startLevel(json) takes the level's waves array ({spawns, startDelay,
+// isFlag}) and lets Go spawn them from the next tick on.
+func startLevel(args []js.Value) (interface{}, error) {
+	var waves []sim.Wave
+	if err := json.Unmarshal([]byte(args[0].String()), &waves); err != nil {
+		return nil, fmt.Errorf("waves: %w", err)
+	}
+	if err := world.StartLevel(waves); err != nil {
+		return nil, err
+	}
+	return true, nil
+}
+
+// startEndless(json) takes the text of endless.json and has Go generate
+// waves for as long as the player survives.
+func startEndless(args []js.Value) (interface{}, error) {
+	cfg, errs := sim.ParseEndless([]byte(args[0].String()), world.Registry.Zombies)
+	if errs != nil {
+		return nil, fmt.Errorf("endless.json: %w", errs)
+	}
+	if err := world.StartEndless(cfg); err != nil {
+		return nil, err
+	}
+	return true, nil
+}
+
+func getWaveProgress(args []js.Value) (interface{}, error) {
+	return world.Waves.Progress(), nil
+}
+
+// getWaveState returns {state, wave, waves, endless}, wave being 1-based;
+// null when no level is running. waves is 0 in endless mode, which has no
+// last wave.
+func getWaveState(args []js.Value) (interface{}, error) {
+	m := world.Waves
+	if m == nil {
+		return nil, nil
+	}
+	waves := len(m.Waves)
+	if m.Endless != nil {
+		waves = 0
+	}
+	return map[string]interface{}{
+		"state":   string(m.State),
+		"wave":    m.Index + 1,
+		"waves":   waves,
+		"endless": m.Endless != nil,
+	}, nil
+}
+
+// --- Game Data ---
+
+// loadRegistry(plants, zombies) takes the text of plants.json and
+// zombies.json. Entities created afterwards use their stats; on error the
+// previous stats stay and every problem is in the message.
+func loadRegistry(args []js.Value) (interface{}, error) {
+	if err := world.LoadRegistry([]byte(args[0].String()), []byte(args[1].String())); err != nil {
+		return nil, err
+	}
+	return true, nil
+}
+
+// --- Input & Replay ---
+
+// commandArg reads {type, row, col, kind, id} into a sim.Command. Missing
+// optional fields default to zero; present ones must have the right type.
+func commandArg(v js.Value) (sim.Command, error) {
+	var cmd sim.Command
+
+	typ := v.Get("type")
+	if typ.Type() != js.TypeString {
+		return cmd, fmt.Errorf("command.type must be a string")
+	}
+	cmd.Type = sim.CommandType(typ.String())
+
+	for _, f := range []struct {
+		name string
+		dst  *int
+	}{{"row", &cmd.Row}, {"col", &cmd.Col}, {"id", &cmd.ID}} {
+		fv := v.Get(f.name)
+		if fv.IsUndefined() {
+			continue
+		}
+		if fv.Type() != js.TypeNumber {
+			return cmd, fmt.Errorf("command.%s must be a number", f.name)
+		}
+		*f.dst = fv.Int()
+	}
+
+	if kind := v.Get("kind"); !kind.IsUndefined() {
+		if kind.Type() != js.TypeString {
+			return cmd, fmt.Errorf("command.kind must be a string")
+		}
+		cmd.Kind = kind.String()
+	}
+	return cmd, nil
+}
+
+// submitCommand({type, row, col, kind, id}) queues player input for the
+// next tick. Results (e.g. plant_placed) arrive as events.
+func submitCommand(args []js.Value) (interface{}, error) {
+	cmd, err := commandArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if err := world.Submit(cmd); err != nil {
+		return nil, err
+	}
+	return true, nil
+}
+
+// startRecording() needs a fresh run, see resetWorld
+func startRecording(args []js.Value) (interface{}, error) {
+	if err := world.StartRecording(); err != nil {
+		return nil, err
+	}
+	return true, nil
+}
+
+// stopRecording() returns the replay as a JSON string
+func stopRecording(args []js.Value) (interface{}, error) {
+	rep := world.StopRecording()
+	if rep == nil {
+		return nil, fmt.Errorf("not recording")
+	}
+	data, err := rep.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func playReplay(args []js.Value) (interface{}, error) {
+	rep, err := sim.ParseReplay([]byte(args[0].String()))
+	if err != nil {
+		return nil, err
+	}
+	if err := world.Play(rep); err != nil {
+		return nil, err
+	}
+	return true, nil
+}
+
+func isReplaying(args []js.Value) (interface{}, error) {
+	return world.Playing(), nil
 }
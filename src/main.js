@@ -270,6 +270,11 @@ document.addEventListener('DOMContentLoaded', () => {
       });
     }
 
+    const checkpointBtn = document.getElementById('checkpoint-btn');
+    if (checkpointBtn) {
+      checkpointBtn.addEventListener('click', () => game.retryCheckpoint());
+    }
+
     const nextLevelBtn = document.getElementById('next-level-btn');
     if (nextLevelBtn) {
       nextLevelBtn.addEventListener('click', () => {
@@ -1,3 +1,7 @@
 module pvz/tools/modmaker
 
 go 1.25.5
+
+require pvz v0.0.0
+
+replace pvz => ../..
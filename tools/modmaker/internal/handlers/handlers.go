@@ -89,6 +89,15 @@ func (h *Handler) HandleData(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		// Game data files must also pass the game's schema. The editor
+		// highlights each {path, message} in the response.
+		if errs := h.validateData(cleanPath, body); len(errs) > 0 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(map[string]interface{}{"errors": errs})
+			return
+		}
+
 		err = os.WriteFile(targetPath, body, 0644)
 		if err != nil {
 			http.Error(w, "Failed to write file", http.StatusInternalServerError)
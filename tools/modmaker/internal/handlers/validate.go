@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"os"
+	"path/filepath"
+
+	"pvz/internal/sim"
+)
+
+// validateData runs the game's own checks on the data files it loads, so a
+// save the game would reject never reaches disk. Other files only need to be
+// valid JSON.
+func (h *Handler) validateData(filename string, body []byte) sim.FieldErrors {
+	switch filename {
+	case "plants.json":
+		_, errs := sim.ValidatePlants(body)
+		return errs
+	case "zombies.json":
+		_, errs := sim.ValidateZombies(body)
+		return errs
+	case "levels.json":
+		_, errs := sim.ValidateLevels(body, h.zombieTypes())
+		return errs
+	case "endless.json":
+		_, errs := sim.ParseEndless(body, h.zombieTypes())
+		return errs
+	}
+	return nil
+}
+
+// zombieTypes reads the zombies.json on disk for checking the zombie types
+// other files name. If it is missing or broken itself, it returns nil and
+// only the other file's own shape is checked.
+func (h *Handler) zombieTypes() map[string]sim.ZombieStats {
+	data, err := os.ReadFile(filepath.Join(h.DataDir, "zombies.json"))
+	if err != nil {
+		return nil
+	}
+	table, errs := sim.ValidateZombies(data)
+	if errs != nil {
+		return nil
+	}
+	return table
+}